@@ -0,0 +1,11 @@
+package main
+
+import "errors"
+
+var (
+	errMissingCommand = errors.New("missing command")
+	errUnknownCommand = errors.New("unknown command")
+	errInvalidFlags   = errors.New("invalid flags")
+	errMissingFlag    = errors.New("missing required flag")
+	errOutputExists   = errors.New("output file already exists")
+)
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"flag"
+	"io"
+
+	"github.com/konstellation-io/krt/pkg/parse"
+)
+
+func runExpand(args []string, stdout, stderr io.Writer) error {
+	flags := flag.NewFlagSet("expand", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+
+	file := flags.String("file", "krt.yaml", "path to the KRT file")
+
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	krtYaml, err := parse.ParseFileToKrt(*file)
+	if err != nil {
+		return err
+	}
+
+	normalized, err := krtYaml.Normalize()
+	if err != nil {
+		return err
+	}
+
+	expandedYaml, err := parse.ParseKrtToYaml(normalized)
+	if err != nil {
+		return err
+	}
+
+	_, err = stdout.Write(expandedYaml)
+
+	return err
+}
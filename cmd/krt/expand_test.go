@@ -0,0 +1,64 @@
+//go:build unit
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/konstellation-io/krt/pkg/errors"
+	"github.com/konstellation-io/krt/pkg/krt"
+	"github.com/konstellation-io/krt/pkg/parse"
+)
+
+func TestRunExpand(t *testing.T) {
+	stdout, stderr, err := runCmd(t, runExpand, "-file", "testdata/product/krt.yaml")
+	require.NoError(t, err)
+	assert.Empty(t, stderr)
+
+	assert.NotContains(t, stdout, "processTemplates", "templates are left out once expanded")
+	assert.NotContains(t, stdout, "extends")
+
+	expanded, err := parse.ParseYamlToKrt([]byte(stdout))
+	require.NoError(t, err)
+	require.NoError(t, expanded.Validate(), "the expanded KRT is valid")
+
+	etl := expanded.Workflows[0].Processes[1]
+	assert.Equal(t, krt.ProcessTypeTask, etl.Type)
+	assert.Equal(t, map[string]string{"LOG_LEVEL": "debug"}, etl.Config)
+	assert.Equal(t, &krt.ResourceLimit{Request: "100m", Limit: "100m"}, etl.ResourceLimits.CPU)
+}
+
+func TestRunExpand_Errors(t *testing.T) {
+	testCases := []struct {
+		name      string
+		args      []string
+		errorType error
+	}{
+		{
+			name:      "non existent file",
+			args:      []string{"-file", "testdata/unknown.yaml"},
+			errorType: errors.ErrReadingFile,
+		},
+		{
+			name:      "invalid yaml",
+			args:      []string{"-file", "testdata/invalid_krt.yaml"},
+			errorType: errors.ErrInvalidYaml,
+		},
+		{
+			name:      "invalid flag",
+			args:      []string{"-unknown"},
+			errorType: errInvalidFlags,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			stdout, _, err := runCmd(t, runExpand, tc.args...)
+			assert.ErrorIs(t, err, tc.errorType)
+			assert.Empty(t, stdout)
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/konstellation-io/krt/pkg/parse"
+)
+
+func runHash(args []string, stdout, stderr io.Writer) error {
+	flags := flag.NewFlagSet("hash", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+
+	file := flags.String("file", "krt.yaml", "path to the KRT file")
+
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	krtYaml, err := parse.ParseFileToKrt(*file)
+	if err != nil {
+		return err
+	}
+
+	fingerprint, err := krtYaml.Fingerprint()
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(stdout, "%s %s (canonical %s)\n\n", *file, fingerprint.Hash, fingerprint.Canonical)
+
+	w := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "WORKFLOW\tPROCESS\tHASH")
+
+	for _, workflow := range fingerprint.Workflows {
+		fmt.Fprintf(w, "%s\t\t%s\n", workflow.Name, workflow.Hash)
+
+		for _, process := range workflow.Processes {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", workflow.Name, process.Name, process.Hash)
+		}
+	}
+
+	return w.Flush()
+}
@@ -0,0 +1,66 @@
+//go:build unit
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/konstellation-io/krt/pkg/errors"
+	"github.com/konstellation-io/krt/pkg/parse"
+)
+
+func TestRunHash(t *testing.T) {
+	stdout, stderr, err := runCmd(t, runHash, "-file", "testdata/product/krt.yaml")
+	require.NoError(t, err)
+	assert.Empty(t, stderr)
+
+	krtYaml, err := parse.ParseFileToKrt("testdata/product/krt.yaml")
+	require.NoError(t, err)
+
+	fingerprint, err := krtYaml.Fingerprint()
+	require.NoError(t, err)
+
+	workflow := fingerprint.Workflows[0]
+
+	assert.Equal(t, "testdata/product/krt.yaml "+fingerprint.Hash+" (canonical v1)\n\n"+
+		"WORKFLOW       PROCESS     HASH\n"+
+		"classificator              "+workflow.Hash+"\n"+
+		"classificator  entrypoint  "+workflow.Processes[0].Hash+"\n"+
+		"classificator  etl         "+workflow.Processes[1].Hash+"\n"+
+		"classificator  exitpoint   "+workflow.Processes[2].Hash+"\n", stdout)
+}
+
+func TestRunHash_Errors(t *testing.T) {
+	testCases := []struct {
+		name      string
+		args      []string
+		errorType error
+	}{
+		{
+			name:      "non existent file",
+			args:      []string{"-file", "testdata/unknown.yaml"},
+			errorType: errors.ErrReadingFile,
+		},
+		{
+			name:      "invalid yaml",
+			args:      []string{"-file", "testdata/invalid_krt.yaml"},
+			errorType: errors.ErrInvalidYaml,
+		},
+		{
+			name:      "invalid flag",
+			args:      []string{"-unknown"},
+			errorType: errInvalidFlags,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			stdout, _, err := runCmd(t, runHash, tc.args...)
+			assert.ErrorIs(t, err, tc.errorType)
+			assert.Empty(t, stdout)
+		})
+	}
+}
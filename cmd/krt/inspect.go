@@ -0,0 +1,54 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/konstellation-io/krt/pkg/parse"
+)
+
+func runInspect(args []string, stdout, stderr io.Writer) error {
+	flags := flag.NewFlagSet("inspect", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+
+	file := flags.String("file", "krt.yaml", "path to the KRT file")
+	workflow := flags.String("workflow", "", "name of the workflow")
+	process := flags.String("process", "", "name of the process")
+
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	if *workflow == "" || *process == "" {
+		return fmt.Errorf("%w: -workflow and -process", errMissingFlag)
+	}
+
+	krtYaml, err := parse.ParseFileToKrt(*file)
+	if err != nil {
+		return err
+	}
+
+	config, err := krtYaml.EffectiveConfig(*workflow, *process)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "KEY\tVALUE\tSOURCE\tSHADOWS")
+
+	for _, key := range config.Keys() {
+		value := config[key]
+
+		shadowed := make([]string, 0, len(value.Shadowed))
+		for _, source := range value.Shadowed {
+			shadowed = append(shadowed, string(source))
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", key, value.Value, value.Source, strings.Join(shadowed, ","))
+	}
+
+	return w.Flush()
+}
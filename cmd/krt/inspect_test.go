@@ -0,0 +1,61 @@
+//go:build unit
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/konstellation-io/krt/pkg/errors"
+)
+
+func TestRunInspect(t *testing.T) {
+	testCases := []struct {
+		name      string
+		args      []string
+		stdout    string
+		errorType error
+	}{
+		{
+			name: "config of a process",
+			args: []string{"-file", "testdata/product/krt.yaml", "-workflow", "classificator", "-process", "etl"},
+			stdout: "KEY        VALUE  SOURCE    SHADOWS\n" +
+				"LOG_LEVEL  debug  process   product\n" +
+				"REGION     us     workflow  product\n",
+		},
+		{
+			name:   "config of a process without config",
+			args:   []string{"-file", "testdata/product/krt.yaml", "-workflow", "classificator", "-process", "entrypoint"},
+			stdout: "KEY        VALUE  SOURCE    SHADOWS\nLOG_LEVEL  info   product   \nREGION     us     workflow  product\n",
+		},
+		{
+			name:      "missing process",
+			args:      []string{"-file", "testdata/product/krt.yaml", "-workflow", "classificator"},
+			errorType: errMissingFlag,
+		},
+		{
+			name:      "non existent process",
+			args:      []string{"-file", "testdata/product/krt.yaml", "-workflow", "classificator", "-process", "unknown"},
+			errorType: errors.ErrProcessNotFound,
+		},
+		{
+			name:      "non existent file",
+			args:      []string{"-file", "testdata/unknown.yaml", "-workflow", "classificator", "-process", "etl"},
+			errorType: errors.ErrReadingFile,
+		},
+		{
+			name:      "invalid flag",
+			args:      []string{"-unknown"},
+			errorType: errInvalidFlags,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			stdout, _, err := runCmd(t, runInspect, tc.args...)
+			assert.ErrorIs(t, err, tc.errorType)
+			assert.Equal(t, tc.stdout, stdout)
+		})
+	}
+}
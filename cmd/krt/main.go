@@ -0,0 +1,90 @@
+// Command krt provides utilities to inspect and manage KRT files.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	// cron timezones are validated against the embedded IANA database, so the result does not depend on the host
+	_ "time/tzdata"
+)
+
+type command struct {
+	name        string
+	description string
+	run         func(args []string, stdout, stderr io.Writer) error
+}
+
+func commands() []command {
+	return []command{
+		{"inspect", "show the effective config of a process", runInspect},
+		{"migrate", "upgrade a KRT file to the current API version", runMigrate},
+		{"expand", "print a KRT file with every process template expanded", runExpand},
+		{"render", "render a KRT template with the values of its parameters", runRender},
+		{"pack", "pack a KRT and its files in a bundle", runPack},
+		{"sign", "sign a KRT or bundle with an ed25519 key", runSign},
+		{"verify", "verify the signature of a KRT or bundle", runVerify},
+		{"hash", "print the hashes of a KRT and of its workflows and processes", runHash},
+	}
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run runs the command given in the arguments and returns the exit code: 0 on success or when the help of
+// a command is requested, 2 for invalid flags, already printed with the usage of the command, and 1 once
+// the error is printed to stderr otherwise.
+func run(args []string, stdout, stderr io.Writer) int {
+	err := runCommand(args, stdout, stderr)
+
+	switch {
+	case err == nil, errors.Is(err, flag.ErrHelp):
+		return 0
+	case errors.Is(err, errInvalidFlags):
+		return 2
+	}
+
+	fmt.Fprintln(stderr, err)
+
+	return 1
+}
+
+func runCommand(args []string, stdout, stderr io.Writer) error {
+	if len(args) == 0 {
+		usage(stderr)
+		return errMissingCommand
+	}
+
+	for _, cmd := range commands() {
+		if cmd.name == args[0] {
+			return cmd.run(args[1:], stdout, stderr)
+		}
+	}
+
+	usage(stderr)
+
+	return fmt.Errorf("%w: %q", errUnknownCommand, args[0])
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: krt <command> [flags]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+
+	for _, cmd := range commands() {
+		fmt.Fprintf(w, "  %-10s %s\n", cmd.name, cmd.description)
+	}
+}
+
+// parseFlags parses the flags of a command, whose flag set prints the errors along with its usage.
+func parseFlags(flags *flag.FlagSet, args []string) error {
+	err := flags.Parse(args)
+	if err != nil && !errors.Is(err, flag.ErrHelp) {
+		return fmt.Errorf("%w: %w", errInvalidFlags, err)
+	}
+
+	return err
+}
@@ -0,0 +1,165 @@
+//go:build unit
+
+package main
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/konstellation-io/krt/pkg/signature"
+)
+
+// runCmd runs a command with the given flags, returning what it wrote to stdout and stderr.
+func runCmd(
+	t *testing.T, runFunc func(args []string, stdout, stderr io.Writer) error, args ...string,
+) (stdout, stderr string, err error) {
+	t.Helper()
+
+	var stdoutBuffer, stderrBuffer bytes.Buffer
+
+	err = runFunc(args, &stdoutBuffer, &stderrBuffer)
+
+	return stdoutBuffer.String(), stderrBuffer.String(), err
+}
+
+// copyDir copies a directory of the testdata in a temporary directory, for the commands writing files.
+func copyDir(t *testing.T, src string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	err := fs.WalkDir(os.DirFS(src), ".", func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		target := filepath.Join(dir, filepath.FromSlash(path))
+		if entry.IsDir() {
+			return os.MkdirAll(target, 0o755)
+		}
+
+		content, err := os.ReadFile(filepath.Join(src, filepath.FromSlash(path)))
+		if err != nil {
+			return err
+		}
+
+		return os.WriteFile(target, content, 0o644)
+	})
+	require.NoError(t, err)
+
+	return dir
+}
+
+// writeKeys writes a new ed25519 key pair in the directory, returning the paths of the private and public keys
+// and the ID of the key.
+func writeKeys(t *testing.T, dir, name string) (privateKeyFile, publicKeyFile, keyID string) {
+	t.Helper()
+
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	privatePem, err := signature.MarshalPrivateKey(privateKey)
+	require.NoError(t, err)
+
+	publicPem, err := signature.MarshalPublicKey(publicKey)
+	require.NoError(t, err)
+
+	privateKeyFile = filepath.Join(dir, name+".key")
+	publicKeyFile = filepath.Join(dir, name+".pub")
+
+	require.NoError(t, os.WriteFile(privateKeyFile, privatePem, 0o600))
+	require.NoError(t, os.WriteFile(publicKeyFile, publicPem, 0o644))
+
+	return privateKeyFile, publicKeyFile, signature.KeyID(publicKey)
+}
+
+func TestRun(t *testing.T) {
+	testCases := []struct {
+		name     string
+		args     []string
+		exitCode int
+		stdout   string
+		stderr   []string
+	}{
+		{
+			name:     "command succeeding",
+			args:     []string{"inspect", "-file", "testdata/product/krt.yaml", "-workflow", "classificator", "-process", "etl"},
+			exitCode: 0,
+			stdout:   "KEY        VALUE  SOURCE    SHADOWS\nLOG_LEVEL  debug  process   product\nREGION     us     workflow  product\n",
+		},
+		{
+			name:     "command failing",
+			args:     []string{"inspect", "-file", "testdata/product/krt.yaml"},
+			exitCode: 1,
+			stderr:   []string{"missing required flag: -workflow and -process\n"},
+		},
+		{
+			name:     "invalid flag",
+			args:     []string{"inspect", "-unknown"},
+			exitCode: 2,
+			stderr:   []string{"flag provided but not defined: -unknown\nUsage of inspect:\n"},
+		},
+		{
+			name:     "help of a command",
+			args:     []string{"inspect", "-help"},
+			exitCode: 0,
+			stderr:   []string{"Usage of inspect:\n", "-workflow string"},
+		},
+		{
+			name:     "missing command",
+			args:     nil,
+			exitCode: 1,
+			stderr:   []string{"Usage: krt <command> [flags]\n", "missing command\n"},
+		},
+		{
+			name:     "unknown command",
+			args:     []string{"unknown"},
+			exitCode: 1,
+			stderr:   []string{"Usage: krt <command> [flags]\n", "unknown command: \"unknown\"\n"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+
+			assert.Equal(t, tc.exitCode, run(tc.args, &stdout, &stderr))
+			assert.Equal(t, tc.stdout, stdout.String())
+
+			if len(tc.stderr) == 0 {
+				assert.Empty(t, stderr.String())
+			}
+
+			for _, stderrPart := range tc.stderr {
+				assert.Contains(t, stderr.String(), stderrPart)
+			}
+		})
+	}
+}
+
+func TestUsage(t *testing.T) {
+	var usageOutput bytes.Buffer
+
+	usage(&usageOutput)
+
+	assert.Equal(t, `Usage: krt <command> [flags]
+
+Commands:
+  inspect    show the effective config of a process
+  migrate    upgrade a KRT file to the current API version
+  expand     print a KRT file with every process template expanded
+  render     render a KRT template with the values of its parameters
+  pack       pack a KRT and its files in a bundle
+  sign       sign a KRT or bundle with an ed25519 key
+  verify     verify the signature of a KRT or bundle
+  hash       print the hashes of a KRT and of its workflows and processes
+`, usageOutput.String(), "commands are listed in the order they were added")
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/konstellation-io/krt/pkg/errors"
+	"github.com/konstellation-io/krt/pkg/parse"
+)
+
+func runMigrate(args []string, stdout, stderr io.Writer) error {
+	flags := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+
+	file := flags.String("file", "krt.yaml", "path to the KRT file")
+	dryRun := flags.Bool("dry-run", false, "print the migrated files instead of writing them back")
+
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(*file)
+
+	// included files are migrated too, by path relative to the directory of the KRT
+	migratedFiles, rewrites, err := parse.MigrateFS(os.DirFS(dir), filepath.Base(*file))
+	if err != nil {
+		return err
+	}
+
+	if len(rewrites) == 0 {
+		fmt.Fprintf(stdout, "%s is up to date\n", *file)
+		return nil
+	}
+
+	paths := make([]string, 0, len(migratedFiles))
+	for path := range migratedFiles {
+		paths = append(paths, path)
+	}
+
+	sort.Strings(paths)
+
+	if *dryRun {
+		for _, path := range paths {
+			fmt.Fprintf(stdout, "--- # %s\n", filepath.Join(dir, path))
+
+			if _, err := stdout.Write(migratedFiles[path]); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	for _, rewrite := range rewrites {
+		fmt.Fprintln(stdout, rewrite)
+	}
+
+	for _, path := range paths {
+		if err := writeBack(filepath.Join(dir, filepath.FromSlash(path)), migratedFiles[path]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeBack replaces the content of an existing file, keeping its permissions.
+func writeBack(file string, content []byte) error {
+	info, err := os.Stat(file)
+	if err != nil {
+		return errors.ReadingFileError(err)
+	}
+
+	return os.WriteFile(file, content, info.Mode().Perm())
+}
@@ -0,0 +1,97 @@
+//go:build unit
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/konstellation-io/krt/pkg/errors"
+)
+
+func TestRunMigrate(t *testing.T) {
+	testCases := []struct {
+		name          string
+		dir           string
+		args          []string
+		stdout        []string
+		migratedFiles bool
+		errorType     error
+	}{
+		{
+			name: "KRT and included files",
+			dir:  "testdata/v1alpha1",
+			stdout: []string{
+				`krt.workflows[0].processes[1].subtopics: declared subtopic "repairs" subscribed to by another process, ` +
+					`in workflow included from "workflows/classificator.yaml"` + "\n" +
+					`krt.apiVersion: set to "krt/v1"` + "\n",
+			},
+			migratedFiles: true,
+		},
+		{
+			name: "dry run",
+			dir:  "testdata/v1alpha1",
+			args: []string{"-dry-run"},
+			stdout: []string{
+				"--- # {dir}/krt.yaml\napiVersion: krt/v1\n",
+				"--- # {dir}/workflows/classificator.yaml\nname: classificator\n",
+				"    subtopics:\n      - repairs\n",
+			},
+		},
+		{
+			name:   "KRT up to date",
+			dir:    "testdata/product",
+			stdout: []string{"{dir}/krt.yaml is up to date\n"},
+		},
+		{
+			name:      "non existent file",
+			dir:       "testdata/product",
+			args:      []string{"-file", "unknown.yaml"},
+			errorType: errors.ErrReadingFile,
+		},
+		{
+			name:      "invalid flag",
+			dir:       "testdata/product",
+			args:      []string{"-unknown"},
+			errorType: errInvalidFlags,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := copyDir(t, tc.dir)
+
+			args := append([]string{"-file", filepath.Join(dir, "krt.yaml")}, tc.args...)
+
+			stdout, _, err := runCmd(t, runMigrate, args...)
+			require.ErrorIs(t, err, tc.errorType)
+
+			if len(tc.stdout) == 0 {
+				assert.Empty(t, stdout)
+			}
+
+			for _, stdoutPart := range tc.stdout {
+				assert.Contains(t, stdout, strings.ReplaceAll(stdoutPart, "{dir}", dir))
+			}
+
+			for _, file := range []string{"krt.yaml", "workflows/classificator.yaml"} {
+				original, err := os.ReadFile(filepath.Join(tc.dir, file))
+				require.NoError(t, err)
+
+				migrated, err := os.ReadFile(filepath.Join(dir, file))
+				require.NoError(t, err)
+
+				if tc.migratedFiles {
+					assert.NotEqual(t, string(original), string(migrated), "%s is written back", file)
+				} else {
+					assert.Equal(t, string(original), string(migrated), "%s is left as it is", file)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/konstellation-io/krt/pkg/bundle"
+	"github.com/konstellation-io/krt/pkg/parse"
+)
+
+func runPack(args []string, stdout, stderr io.Writer) error {
+	flags := flag.NewFlagSet("pack", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+
+	dir := flags.String("dir", ".", "directory with the KRT and its files")
+	root := flags.String("root", "krt.yaml", "path of the KRT in the directory")
+	out := flags.String("out", "product"+bundle.Extension, "path of the bundle to write")
+
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	if _, err := os.Lstat(*out); err == nil {
+		return fmt.Errorf("%w: %s", errOutputExists, *out)
+	}
+
+	krtYaml, err := parse.ParseFS(os.DirFS(*dir), *root)
+	if err != nil {
+		return err
+	}
+
+	if err := krtYaml.Validate(); err != nil {
+		return err
+	}
+
+	// packed in memory, so a failed pack leaves no bundle behind
+	var packed bytes.Buffer
+
+	manifest, err := bundle.PackDir(&packed, *dir, *root, outputInDir(*dir, *out)...)
+	if err != nil {
+		return err
+	}
+
+	if err := writeNewFile(*out, packed.Bytes()); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(stdout, "packed %d files in %s\n", len(manifest.Files), *out)
+
+	return nil
+}
+
+// outputInDir returns the path of the output file relative to the directory packed, if it is inside it.
+func outputInDir(dir, out string) []string {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil
+	}
+
+	absOut, err := filepath.Abs(out)
+	if err != nil {
+		return nil
+	}
+
+	relOut, err := filepath.Rel(absDir, absOut)
+	if err != nil || !filepath.IsLocal(relOut) {
+		return nil
+	}
+
+	return []string{filepath.ToSlash(relOut)}
+}
+
+// writeNewFile writes a file, failing if it already exists.
+func writeNewFile(name string, content []byte) error {
+	file, err := os.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return err
+	}
+
+	_, err = file.Write(content)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+
+	return err
+}
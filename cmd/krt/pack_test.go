@@ -0,0 +1,85 @@
+//go:build unit
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/konstellation-io/krt/pkg/bundle"
+	"github.com/konstellation-io/krt/pkg/errors"
+)
+
+func TestRunPack(t *testing.T) {
+	dir := copyDir(t, "testdata/product")
+	out := filepath.Join(dir, "product.krt")
+
+	stdout, stderr, err := runCmd(t, runPack, "-dir", dir, "-out", out)
+	require.NoError(t, err)
+	assert.Equal(t, "packed 2 files in "+out+"\n", stdout)
+	assert.Empty(t, stderr)
+
+	bundleFile, err := os.Open(out)
+	require.NoError(t, err)
+	defer bundleFile.Close()
+
+	krtBundle, err := bundle.Read(bundleFile)
+	require.NoError(t, err)
+	assert.Equal(t, "krt.yaml", krtBundle.Manifest.Root)
+	assert.Equal(t, "krt.yaml", krtBundle.Manifest.Files[0].Path)
+	assert.Equal(t, "workflows/classificator.yaml", krtBundle.Manifest.Files[1].Path, "the bundle written is not packed")
+}
+
+func TestRunPack_Errors(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "product.krt")
+	existing := filepath.Join(filepath.Dir(out), "existing.krt")
+	require.NoError(t, os.WriteFile(existing, []byte("bundle"), 0o644))
+
+	invalidDir := copyDir(t, "testdata/product")
+	workflowFile := filepath.Join(invalidDir, "workflows", "classificator.yaml")
+
+	workflowYaml, err := os.ReadFile(workflowFile)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(workflowFile, bytes.Replace(workflowYaml, []byte("type: data"), []byte("type: unknown"), 1), 0o644))
+
+	testCases := []struct {
+		name      string
+		args      []string
+		errorType error
+	}{
+		{
+			name:      "existing output file",
+			args:      []string{"-dir", "testdata/product", "-out", existing},
+			errorType: errOutputExists,
+		},
+		{
+			name:      "invalid KRT",
+			args:      []string{"-dir", invalidDir, "-out", out},
+			errorType: errors.ErrInvalidWorkflowType,
+		},
+		{
+			name:      "non existent root",
+			args:      []string{"-dir", "testdata/product", "-root", "unknown.yaml", "-out", out},
+			errorType: errors.ErrReadingFile,
+		},
+		{
+			name:      "invalid flag",
+			args:      []string{"-unknown"},
+			errorType: errInvalidFlags,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			stdout, _, err := runCmd(t, runPack, tc.args...)
+			assert.ErrorIs(t, err, tc.errorType)
+			assert.Empty(t, stdout)
+			assert.NoFileExists(t, out, "no bundle is written")
+		})
+	}
+}
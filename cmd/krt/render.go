@@ -0,0 +1,52 @@
+package main
+
+import (
+	"flag"
+	"io"
+
+	"github.com/konstellation-io/krt/pkg/parse"
+)
+
+func runRender(args []string, stdout, stderr io.Writer) error {
+	flags := flag.NewFlagSet("render", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+
+	templateFile := flags.String("template", "krt.template.yaml", "path to the KRT template")
+	valuesFile := flags.String("values", "", "path to the values of the template parameters")
+
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	template, err := parse.ParseTemplateFile(*templateFile)
+	if err != nil {
+		return err
+	}
+
+	values := map[string]string{}
+
+	if *valuesFile != "" {
+		values, err = parse.ParseValuesFile(*valuesFile)
+		if err != nil {
+			return err
+		}
+	}
+
+	renderedKrt, err := parse.RenderTemplate(template, values)
+	if err != nil {
+		return err
+	}
+
+	if err := renderedKrt.Validate(); err != nil {
+		return err
+	}
+
+	renderedYaml, err := parse.ParseKrtToYaml(renderedKrt)
+	if err != nil {
+		return err
+	}
+
+	_, err = stdout.Write(renderedYaml)
+
+	return err
+}
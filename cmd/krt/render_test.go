@@ -0,0 +1,67 @@
+//go:build unit
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/konstellation-io/krt/pkg/errors"
+	"github.com/konstellation-io/krt/pkg/parse"
+)
+
+func TestRunRender(t *testing.T) {
+	stdout, stderr, err := runCmd(t, runRender,
+		"-template", "testdata/templates/krt.template.yaml", "-values", "testdata/templates/values.yaml")
+	require.NoError(t, err)
+	assert.Empty(t, stderr)
+
+	rendered, err := parse.ParseYamlToKrt([]byte(stdout))
+	require.NoError(t, err)
+
+	assert.Equal(t, "Email classificator for acme.", rendered.Description)
+	assert.Equal(t, map[string]string{"CUSTOMER": "acme"}, rendered.Config)
+	assert.Equal(t, 3, *rendered.Workflows[0].Processes[1].Replicas)
+}
+
+func TestRunRender_Errors(t *testing.T) {
+	testCases := []struct {
+		name        string
+		args        []string
+		errorType   error
+		errorString string
+	}{
+		{
+			name:        "missing required parameter",
+			args:        []string{"-template", "testdata/templates/krt.template.yaml"},
+			errorType:   errors.ErrMissingTemplateParameter,
+			errorString: `"customer", in krt.description, krt.config.CUSTOMER`,
+		},
+		{
+			name:      "non existent values file",
+			args:      []string{"-template", "testdata/templates/krt.template.yaml", "-values", "testdata/unknown.yaml"},
+			errorType: errors.ErrReadingFile,
+		},
+		{
+			name:      "non existent template",
+			args:      []string{"-template", "testdata/unknown.yaml"},
+			errorType: errors.ErrReadingFile,
+		},
+		{
+			name:      "invalid flag",
+			args:      []string{"-unknown"},
+			errorType: errInvalidFlags,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			stdout, _, err := runCmd(t, runRender, tc.args...)
+			assert.ErrorIs(t, err, tc.errorType)
+			assert.ErrorContains(t, err, tc.errorString)
+			assert.Empty(t, stdout)
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/konstellation-io/krt/pkg/bundle"
+	"github.com/konstellation-io/krt/pkg/errors"
+	"github.com/konstellation-io/krt/pkg/parse"
+	"github.com/konstellation-io/krt/pkg/signature"
+)
+
+func runSign(args []string, stdout, stderr io.Writer) error {
+	flags := flag.NewFlagSet("sign", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+
+	file := flags.String("file", "", "path of the KRT or bundle to sign")
+	key := flags.String("key", "", "path of the PEM encoded ed25519 private key")
+	out := flags.String("out", "", "path of the signature to write, the signed file with "+signature.Extension+" by default")
+
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	if *file == "" || *key == "" {
+		return fmt.Errorf("%w: -file and -key", errMissingFlag)
+	}
+
+	if *out == "" {
+		*out = *file + signature.Extension
+	}
+
+	keyPem, err := os.ReadFile(*key)
+	if err != nil {
+		return errors.ReadingFileError(err)
+	}
+
+	privateKey, err := signature.ParsePrivateKey(keyPem)
+	if err != nil {
+		return err
+	}
+
+	content, err := readSignable(*file)
+	if err != nil {
+		return err
+	}
+
+	sig, err := signature.Sign(content, privateKey)
+	if err != nil {
+		return err
+	}
+
+	sigYaml, err := sig.Marshal()
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(*out, sigYaml, 0o644); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(stdout, "signed %s with key %s in %s\n", *file, sig.KeyID, *out)
+
+	return nil
+}
+
+// readSignable reads the content signed for a file: the manifest of a bundle, or the KRT of any other
+// file once validated.
+func readSignable(file string) (signature.Signable, error) {
+	if filepath.Ext(file) == bundle.Extension {
+		bundleFile, err := os.Open(file)
+		if err != nil {
+			return nil, errors.ReadingFileError(err)
+		}
+		defer bundleFile.Close()
+
+		krtBundle, err := bundle.Read(bundleFile)
+		if err != nil {
+			return nil, err
+		}
+
+		return &krtBundle.Manifest, nil
+	}
+
+	krtYaml, err := parse.ParseFileToKrt(file)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := krtYaml.Validate(); err != nil {
+		return nil, err
+	}
+
+	return krtYaml, nil
+}
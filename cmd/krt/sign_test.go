@@ -0,0 +1,121 @@
+//go:build unit
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/konstellation-io/krt/pkg/errors"
+	"github.com/konstellation-io/krt/pkg/signature"
+)
+
+// signedProduct copies the product of the testdata and packs it in a temporary directory, returning the
+// directory, the path of the bundle and a key pair to sign them.
+func signedProduct(t *testing.T) (dir, bundleFile, privateKeyFile, publicKeyFile, keyID string) {
+	t.Helper()
+
+	dir = copyDir(t, "testdata/product")
+	bundleFile = filepath.Join(t.TempDir(), "product.krt")
+
+	_, _, err := runCmd(t, runPack, "-dir", dir, "-out", bundleFile)
+	require.NoError(t, err)
+
+	privateKeyFile, publicKeyFile, keyID = writeKeys(t, t.TempDir(), "release")
+
+	return dir, bundleFile, privateKeyFile, publicKeyFile, keyID
+}
+
+func TestRunSign(t *testing.T) {
+	dir, bundleFile, privateKeyFile, _, keyID := signedProduct(t)
+	krtFile := filepath.Join(dir, "krt.yaml")
+	out := filepath.Join(t.TempDir(), "krt.sig")
+
+	testCases := []struct {
+		name    string
+		args    []string
+		sigFile string
+	}{
+		{
+			name:    "KRT",
+			args:    []string{"-file", krtFile, "-key", privateKeyFile},
+			sigFile: krtFile + signature.Extension,
+		},
+		{
+			name:    "bundle",
+			args:    []string{"-file", bundleFile, "-key", privateKeyFile},
+			sigFile: bundleFile + signature.Extension,
+		},
+		{
+			name:    "signature written in another file",
+			args:    []string{"-file", krtFile, "-key", privateKeyFile, "-out", out},
+			sigFile: out,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			stdout, stderr, err := runCmd(t, runSign, tc.args...)
+			require.NoError(t, err)
+			assert.Equal(t, "signed "+tc.args[1]+" with key "+keyID+" in "+tc.sigFile+"\n", stdout)
+			assert.Empty(t, stderr)
+
+			sigYaml, err := os.ReadFile(tc.sigFile)
+			require.NoError(t, err)
+
+			sig, err := signature.ParseSignature(sigYaml)
+			require.NoError(t, err)
+			assert.Equal(t, keyID, sig.KeyID)
+		})
+	}
+}
+
+func TestRunSign_Errors(t *testing.T) {
+	dir, _, privateKeyFile, publicKeyFile, _ := signedProduct(t)
+	krtFile := filepath.Join(dir, "krt.yaml")
+
+	testCases := []struct {
+		name      string
+		args      []string
+		errorType error
+	}{
+		{
+			name:      "missing key",
+			args:      []string{"-file", krtFile},
+			errorType: errMissingFlag,
+		},
+		{
+			name:      "non existent key",
+			args:      []string{"-file", krtFile, "-key", filepath.Join(dir, "unknown.key")},
+			errorType: errors.ErrReadingFile,
+		},
+		{
+			name:      "public key",
+			args:      []string{"-file", krtFile, "-key", publicKeyFile},
+			errorType: errors.ErrInvalidKey,
+		},
+		{
+			name:      "invalid KRT",
+			args:      []string{"-file", "testdata/invalid_krt.yaml", "-key", privateKeyFile},
+			errorType: errors.ErrInvalidYaml,
+		},
+		{
+			name:      "invalid flag",
+			args:      []string{"-unknown"},
+			errorType: errInvalidFlags,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			stdout, _, err := runCmd(t, runSign, tc.args...)
+			assert.ErrorIs(t, err, tc.errorType)
+			assert.Empty(t, stdout)
+			assert.NoFileExists(t, krtFile+signature.Extension)
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/konstellation-io/krt/pkg/errors"
+	"github.com/konstellation-io/krt/pkg/signature"
+)
+
+func runVerify(args []string, stdout, stderr io.Writer) error {
+	flags := flag.NewFlagSet("verify", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+
+	file := flags.String("file", "", "path of the KRT or bundle to verify")
+	sigFile := flags.String("signature", "", "path of the signature, the verified file with "+signature.Extension+" by default")
+	keys := flags.String("keys", "", "path of the PEM encoded ed25519 public keys trusted")
+
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	if *file == "" || *keys == "" {
+		return fmt.Errorf("%w: -file and -keys", errMissingFlag)
+	}
+
+	if *sigFile == "" {
+		*sigFile = *file + signature.Extension
+	}
+
+	keysPem, err := os.ReadFile(*keys)
+	if err != nil {
+		return errors.ReadingFileError(err)
+	}
+
+	keySet, err := signature.ParseKeySet(keysPem)
+	if err != nil {
+		return err
+	}
+
+	sigYaml, err := os.ReadFile(*sigFile)
+	if err != nil {
+		return errors.ReadingFileError(err)
+	}
+
+	sig, err := signature.ParseSignature(sigYaml)
+	if err != nil {
+		return err
+	}
+
+	content, err := readSignable(*file)
+	if err != nil {
+		return err
+	}
+
+	if err := keySet.Verify(content, sig); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(stdout, "%s is signed with trusted key %s\n", *file, sig.KeyID)
+
+	return nil
+}
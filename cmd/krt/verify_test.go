@@ -0,0 +1,99 @@
+//go:build unit
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/konstellation-io/krt/pkg/errors"
+)
+
+func TestRunVerify(t *testing.T) {
+	dir, bundleFile, privateKeyFile, publicKeyFile, keyID := signedProduct(t)
+	krtFile := filepath.Join(dir, "krt.yaml")
+	_, untrustedKeyFile, _ := writeKeys(t, t.TempDir(), "untrusted")
+
+	for _, file := range []string{krtFile, bundleFile} {
+		_, _, err := runCmd(t, runSign, "-file", file, "-key", privateKeyFile)
+		require.NoError(t, err)
+	}
+
+	otherSigFile := filepath.Join(t.TempDir(), "krt.sig")
+	_, _, err := runCmd(t, runSign, "-file", krtFile, "-key", privateKeyFile, "-out", otherSigFile)
+	require.NoError(t, err)
+
+	// copied along with its signature, once signed
+	modifiedDir := copyDir(t, dir)
+	modifiedKrtFile := filepath.Join(modifiedDir, "krt.yaml")
+	workflowFile := filepath.Join(modifiedDir, "workflows", "classificator.yaml")
+
+	workflowYaml, err := os.ReadFile(workflowFile)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(workflowFile, bytes.ReplaceAll(workflowYaml, []byte(":latest"), []byte(":v2")), 0o644))
+
+	testCases := []struct {
+		name      string
+		args      []string
+		stdout    string
+		errorType error
+	}{
+		{
+			name:   "signed KRT",
+			args:   []string{"-file", krtFile, "-keys", publicKeyFile},
+			stdout: krtFile + " is signed with trusted key " + keyID + "\n",
+		},
+		{
+			name:   "signed bundle",
+			args:   []string{"-file", bundleFile, "-keys", publicKeyFile},
+			stdout: bundleFile + " is signed with trusted key " + keyID + "\n",
+		},
+		{
+			name:   "signature in another file",
+			args:   []string{"-file", krtFile, "-signature", otherSigFile, "-keys", publicKeyFile},
+			stdout: krtFile + " is signed with trusted key " + keyID + "\n",
+		},
+		{
+			name:      "untrusted key",
+			args:      []string{"-file", krtFile, "-keys", untrustedKeyFile},
+			errorType: errors.ErrUntrustedKey,
+		},
+		{
+			name:      "KRT modified after signing",
+			args:      []string{"-file", modifiedKrtFile, "-keys", publicKeyFile},
+			errorType: errors.ErrInvalidSignature,
+		},
+		{
+			name:      "missing signature",
+			args:      []string{"-file", krtFile, "-signature", filepath.Join(dir, "unknown.sig"), "-keys", publicKeyFile},
+			errorType: errors.ErrReadingFile,
+		},
+		{
+			name:      "missing keys",
+			args:      []string{"-file", krtFile},
+			errorType: errMissingFlag,
+		},
+		{
+			name:      "invalid flag",
+			args:      []string{"-unknown"},
+			errorType: errInvalidFlags,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			stdout, stderr, err := runCmd(t, runVerify, tc.args...)
+			assert.ErrorIs(t, err, tc.errorType)
+			assert.Equal(t, tc.stdout, stdout)
+
+			if tc.errorType == nil {
+				assert.Empty(t, stderr)
+			}
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package cronutil
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidExpression = errors.New("malformed expression")
+	ErrInvalidField      = errors.New("invalid field")
+)
+
+// Schedule is a parsed cron expression, holding the values that match each field in ascending order.
+type Schedule struct {
+	Minutes     []int
+	Hours       []int
+	DaysOfMonth []int
+	Months      []int
+	DaysOfWeek  []int
+}
+
+type field struct {
+	name  string
+	min   int
+	max   int
+	names map[string]int
+}
+
+func fields() []field {
+	return []field{
+		{name: "minute", min: 0, max: 59},
+		{name: "hour", min: 0, max: 23},
+		{name: "day of month", min: 1, max: 31},
+		{name: "month", min: 1, max: 12, names: map[string]int{
+			"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+			"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+		}},
+		// Both 0 and 7 stand for Sunday.
+		{name: "day of week", min: 0, max: 7, names: map[string]int{
+			"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6,
+		}},
+	}
+}
+
+func descriptors() map[string]string {
+	return map[string]string{
+		"@yearly":   "0 0 1 1 *",
+		"@annually": "0 0 1 1 *",
+		"@monthly":  "0 0 1 * *",
+		"@weekly":   "0 0 * * 0",
+		"@daily":    "0 0 * * *",
+		"@midnight": "0 0 * * *",
+		"@hourly":   "0 * * * *",
+	}
+}
+
+// Parse parses a standard five field cron expression (minute, hour, day of month, month and day of week)
+// or one of the predefined descriptors such as "@daily".
+//
+// Fields accept "*", single values, ranges ("1-5"), steps ("*/15", "0-30/10", "5/10" standing for "5-59/10")
+// and comma separated lists of them. Months and days of week also accept their three letter English names.
+func Parse(expression string) (*Schedule, error) {
+	expression = strings.TrimSpace(expression)
+
+	if strings.HasPrefix(expression, "@") {
+		standard, ok := descriptors()[strings.ToLower(expression)]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown descriptor %q", ErrInvalidExpression, expression)
+		}
+
+		expression = standard
+	}
+
+	parts := strings.Fields(expression)
+	cronFields := fields()
+
+	if len(parts) != len(cronFields) {
+		return nil, fmt.Errorf("%w: expected %d fields, found %d", ErrInvalidExpression, len(cronFields), len(parts))
+	}
+
+	values := make([][]int, len(cronFields))
+
+	for idx, cronField := range cronFields {
+		fieldValues, err := parseField(parts[idx], cronField)
+		if err != nil {
+			return nil, err
+		}
+
+		values[idx] = fieldValues
+	}
+
+	return &Schedule{
+		Minutes:     values[0],
+		Hours:       values[1],
+		DaysOfMonth: values[2],
+		Months:      values[3],
+		DaysOfWeek:  normalizeSunday(values[4]),
+	}, nil
+}
+
+func parseField(value string, cronField field) ([]int, error) {
+	matches := make([]bool, cronField.max+1)
+
+	for _, item := range strings.Split(value, ",") {
+		if err := parseItem(item, cronField, matches); err != nil {
+			return nil, fmt.Errorf("%w: %s %q: %w", ErrInvalidField, cronField.name, value, err)
+		}
+	}
+
+	values := make([]int, 0, len(matches))
+
+	for fieldValue, ok := range matches {
+		if ok {
+			values = append(values, fieldValue)
+		}
+	}
+
+	return values, nil
+}
+
+func parseItem(item string, cronField field, matches []bool) error {
+	rangeExpr, step := item, 1
+
+	before, after, hasStep := strings.Cut(item, "/")
+	if hasStep {
+		parsedStep, err := strconv.Atoi(after)
+		if err != nil || parsedStep < 1 {
+			return fmt.Errorf("invalid step %q", after)
+		}
+
+		rangeExpr, step = before, parsedStep
+	}
+
+	start, end, err := parseRange(rangeExpr, cronField)
+	if err != nil {
+		return err
+	}
+
+	if hasStep && !strings.Contains(rangeExpr, "-") && rangeExpr != "*" {
+		// A step over a single value, such as "5/10", runs from that value to the end of the range.
+		end = cronField.max
+	}
+
+	for fieldValue := start; fieldValue <= end; fieldValue += step {
+		matches[fieldValue] = true
+	}
+
+	return nil
+}
+
+func parseRange(rangeExpr string, cronField field) (start, end int, err error) {
+	if rangeExpr == "*" {
+		return cronField.min, cronField.max, nil
+	}
+
+	before, after, isRange := strings.Cut(rangeExpr, "-")
+
+	start, err = parseValue(before, cronField)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if !isRange {
+		return start, start, nil
+	}
+
+	end, err = parseValue(after, cronField)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if end < start {
+		return 0, 0, fmt.Errorf("range %q ends before it starts", rangeExpr)
+	}
+
+	return start, end, nil
+}
+
+func parseValue(value string, cronField field) (int, error) {
+	if named, ok := cronField.names[strings.ToUpper(value)]; ok {
+		return named, nil
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", value)
+	}
+
+	if parsed < cronField.min || parsed > cronField.max {
+		return 0, fmt.Errorf("value %d out of range %d-%d", parsed, cronField.min, cronField.max)
+	}
+
+	return parsed, nil
+}
+
+func normalizeSunday(daysOfWeek []int) []int {
+	if len(daysOfWeek) == 0 || daysOfWeek[len(daysOfWeek)-1] != 7 {
+		return daysOfWeek
+	}
+
+	daysOfWeek = daysOfWeek[:len(daysOfWeek)-1]
+	if len(daysOfWeek) > 0 && daysOfWeek[0] == 0 {
+		return daysOfWeek
+	}
+
+	return append([]int{0}, daysOfWeek...)
+}
@@ -0,0 +1,72 @@
+package cronutil_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/konstellation-io/krt/internal/cronutil"
+)
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		name          string
+		expression    string
+		expectedError error
+	}{
+		{"Every minute", "* * * * *", nil},
+		{"Steps, ranges and lists", "*/15 8-18 1,15 * MON-FRI", nil},
+		{"Step over a range", "0-30/10 * * * *", nil},
+		{"Month names", "0 0 1 jan,jul *", nil},
+		{"Descriptor", "@daily", nil},
+		{"Unknown descriptor", "@sometimes", cronutil.ErrInvalidExpression},
+		{"Missing fields", "* * * *", cronutil.ErrInvalidExpression},
+		{"Too many fields", "0 * * * * *", cronutil.ErrInvalidExpression},
+		{"Minute out of range", "60 * * * *", cronutil.ErrInvalidField},
+		{"Inverted range", "* 18-8 * * *", cronutil.ErrInvalidField},
+		{"Invalid step", "*/0 * * * *", cronutil.ErrInvalidField},
+		{"Invalid value", "* * * * funday", cronutil.ErrInvalidField},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := cronutil.Parse(tc.expression)
+			assert.ErrorIs(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestParse_Schedule(t *testing.T) {
+	schedule, err := cronutil.Parse("5/20 9-11 * FEB SAT-7")
+	require.NoError(t, err)
+
+	assert.Equal(t, []int{5, 25, 45}, schedule.Minutes)
+	assert.Equal(t, []int{9, 10, 11}, schedule.Hours)
+	assert.Len(t, schedule.DaysOfMonth, 31)
+	assert.Equal(t, []int{2}, schedule.Months)
+	assert.Equal(t, []int{0, 6}, schedule.DaysOfWeek)
+}
+
+func TestParse_Steps(t *testing.T) {
+	testCases := []struct {
+		name            string
+		minutes         string
+		expectedMinutes []int
+	}{
+		{"Step over every value", "*/20", []int{0, 20, 40}},
+		{"Step over a range", "10-30/10", []int{10, 20, 30}},
+		{"Step over a single value", "40/5", []int{40, 45, 50, 55}},
+		{"Step of one over a single value", "57/1", []int{57, 58, 59}},
+		{"Step over a range of one value", "5-5/10", []int{5}},
+		{"Steps in a list", "0/30,15/30", []int{0, 15, 30, 45}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			schedule, err := cronutil.Parse(tc.minutes + " * * * *")
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedMinutes, schedule.Minutes)
+		})
+	}
+}
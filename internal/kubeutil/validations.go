@@ -11,12 +11,14 @@ const (
 	_maxNameLength         = 63
 	_maxValueLength        = 63
 	_maxDNSSubdomainLength = 253
+	_maxAnnotationsSize    = 256 * 1024
 
 	_alphaNumFmt     = "[A-Za-z0-9]"
 	_alphaNumWithFmt = "[-A-Za-z0-9_.]"
 	_validDNSFmt     = "[a-z0-9]([-a-z0-9]*[a-z0-9])?(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*"
 
 	_qualifiedNameFmt = "(" + _alphaNumFmt + _alphaNumWithFmt + "*)?" + _alphaNumFmt
+	_quantityFmt      = "[+-]?([0-9]+(\\.[0-9]*)?|\\.[0-9]+)([KMGTPE]i|[numkMGTPE]|[eE][+-]?[0-9]+)?"
 )
 
 var (
@@ -24,12 +26,54 @@ var (
 	ErrInvalidKeyFormat = errors.New("invalid key format: key must be a valid name with an optional DNS subdomain prefix joined by '/'")
 	ErrInvalidKeyName   = errors.New("invalid key name")
 	ErrInvalidValue     = errors.New("invalid value")
+	ErrInvalidHostname  = errors.New("invalid hostname")
+	ErrInvalidDNSName   = errors.New("invalid DNS subdomain name")
+	ErrAnnotationsSize  = errors.New("annotations too large")
+	ErrInvalidQuantity  = errors.New("invalid quantity")
 
 	_validQualifiedNameRegexp = regexp.MustCompile("^" + _qualifiedNameFmt + "$")
 	_validDNSSubdomainRegexp  = regexp.MustCompile("^" + _validDNSFmt + "(\\." + _validDNSFmt + ")*$")
+	_validQuantityRegexp      = regexp.MustCompile("^" + _quantityFmt + "$")
 )
 
 func ValidateNodeSelectorKey(value string) error {
+	return validateQualifiedKey(value)
+}
+
+// ValidateLabelKey checks the key is a valid name with an optional DNS subdomain prefix.
+func ValidateLabelKey(key string) error {
+	return validateQualifiedKey(key)
+}
+
+// ValidateLabelValue checks the value is a valid label value, unlike node selectors, it can be empty.
+func ValidateLabelValue(value string) error {
+	if value == "" {
+		return nil
+	}
+
+	return ValidateNodeSelectorValue(value)
+}
+
+// ValidateAnnotationKey checks the key follows the same rules as label keys.
+func ValidateAnnotationKey(key string) error {
+	return validateQualifiedKey(key)
+}
+
+// ValidateAnnotationsSize checks the total size of the annotations, keys and values, does not exceed 256KiB.
+func ValidateAnnotationsSize(annotations map[string]string) error {
+	size := 0
+	for key, value := range annotations {
+		size += len(key) + len(value)
+	}
+
+	if size > _maxAnnotationsSize {
+		return fmt.Errorf("%w: total size must be less than %d bytes", ErrAnnotationsSize, _maxAnnotationsSize)
+	}
+
+	return nil
+}
+
+func validateQualifiedKey(value string) error {
 	keySections := strings.Split(value, "/")
 
 	switch len(keySections) {
@@ -114,3 +158,52 @@ func ValidateNodeSelectorValue(value string) error {
 
 	return nil
 }
+
+// ValidateHostname checks the host is a valid DNS subdomain, optionally starting with a "*." wildcard.
+func ValidateHostname(host string) error {
+	if host == "" {
+		return fmt.Errorf("%w: hostname cannot be empty", ErrInvalidHostname)
+	}
+
+	if len(host) > _maxDNSSubdomainLength {
+		return fmt.Errorf("%w: hostname too long", ErrInvalidHostname)
+	}
+
+	if !_validDNSSubdomainRegexp.MatchString(strings.TrimPrefix(host, "*.")) {
+		return fmt.Errorf("%w: hostname must match the regexp %q",
+			ErrInvalidHostname,
+			_validDNSFmt,
+		)
+	}
+
+	return nil
+}
+
+// ValidateDNSSubdomain checks the name can be used as a Kubernetes object name, such as a secret name.
+func ValidateDNSSubdomain(name string) error {
+	if name == "" {
+		return fmt.Errorf("%w: name cannot be empty", ErrInvalidDNSName)
+	}
+
+	if len(name) > _maxDNSSubdomainLength {
+		return fmt.Errorf("%w: name too long", ErrInvalidDNSName)
+	}
+
+	if !_validDNSSubdomainRegexp.MatchString(name) {
+		return fmt.Errorf("%w: name must match the regexp %q",
+			ErrInvalidDNSName,
+			_validDNSFmt,
+		)
+	}
+
+	return nil
+}
+
+// ValidateQuantity checks the value is a Kubernetes quantity, such as "100m", "0.5", "1Gi" or "1e3".
+func ValidateQuantity(value string) error {
+	if !_validQuantityRegexp.MatchString(value) {
+		return fmt.Errorf("%w: quantity must match the regexp %q", ErrInvalidQuantity, _quantityFmt)
+	}
+
+	return nil
+}
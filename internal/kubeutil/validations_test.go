@@ -1,6 +1,7 @@
 package kubeutil_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/konstellation-io/krt/internal/kubeutil"
@@ -46,3 +47,90 @@ func TestValidateNodeSelectorValue(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateLabelValue(t *testing.T) {
+	testCases := []struct {
+		name          string
+		value         string
+		expectedError error
+	}{
+		{"Valid value", "valid-value", nil},
+		{"Valid empty value", "", nil},
+		{"Invalid value", "invalid value", kubeutil.ErrInvalidValue},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.ErrorIs(t, kubeutil.ValidateLabelValue(tc.value), tc.expectedError)
+		})
+	}
+}
+
+func TestValidateAnnotationsSize(t *testing.T) {
+	assert.NoError(t, kubeutil.ValidateAnnotationsSize(map[string]string{"description": "valid annotation"}))
+	assert.ErrorIs(t,
+		kubeutil.ValidateAnnotationsSize(map[string]string{"description": strings.Repeat("a", 256*1024)}),
+		kubeutil.ErrAnnotationsSize,
+	)
+}
+
+func TestValidateHostname(t *testing.T) {
+	testCases := []struct {
+		name          string
+		host          string
+		expectedError error
+	}{
+		{"Valid hostname", "api.konstellation.io", nil},
+		{"Valid wildcard hostname", "*.konstellation.io", nil},
+		{"Invalid hostname with uppercase letters", "API.konstellation.io", kubeutil.ErrInvalidHostname},
+		{"Invalid hostname with a port", "konstellation.io:8080", kubeutil.ErrInvalidHostname},
+		{"Invalid empty hostname", "", kubeutil.ErrInvalidHostname},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.ErrorIs(t, kubeutil.ValidateHostname(tc.host), tc.expectedError)
+		})
+	}
+}
+
+func TestValidateDNSSubdomain(t *testing.T) {
+	testCases := []struct {
+		name          string
+		value         string
+		expectedError error
+	}{
+		{"Valid name", "tls-secret", nil},
+		{"Invalid name", "tls_secret", kubeutil.ErrInvalidDNSName},
+		{"Invalid empty name", "", kubeutil.ErrInvalidDNSName},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.ErrorIs(t, kubeutil.ValidateDNSSubdomain(tc.value), tc.expectedError)
+		})
+	}
+}
+
+func TestValidateQuantity(t *testing.T) {
+	testCases := []struct {
+		name          string
+		value         string
+		expectedError error
+	}{
+		{"Valid integer quantity", "2", nil},
+		{"Valid decimal quantity", "0.5", nil},
+		{"Valid quantity with a decimal suffix", "100m", nil},
+		{"Valid quantity with a binary suffix", "1Gi", nil},
+		{"Valid quantity with an exponent", "1e3", nil},
+		{"Invalid quantity with an unknown suffix", "1Gb", kubeutil.ErrInvalidQuantity},
+		{"Invalid quantity without number", "Gi", kubeutil.ErrInvalidQuantity},
+		{"Invalid empty quantity", "", kubeutil.ErrInvalidQuantity},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.ErrorIs(t, kubeutil.ValidateQuantity(tc.value), tc.expectedError)
+		})
+	}
+}
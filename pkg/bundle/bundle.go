@@ -0,0 +1,512 @@
+// Package bundle packs a KRT together with the files it includes and its assets, such as config files,
+// schemas or docs, in a single .krt file.
+//
+// A bundle is a tar.gz archive with a manifest naming the root KRT and listing the SHA-256 checksum of every
+// other file in the archive.
+package bundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/konstellation-io/krt/pkg/errors"
+	"github.com/konstellation-io/krt/pkg/signature"
+)
+
+const (
+	// Extension is the extension of bundle files.
+	Extension = ".krt"
+
+	// ManifestPath is the path of the manifest in the bundle.
+	ManifestPath = "manifest.yaml"
+
+	// CanonicalVersion is the version of the canonical serialization of manifests, see Manifest.Canonical.
+	CanonicalVersion = "v1"
+
+	// SignatureDomain is the domain of manifest signatures, so they cannot be taken for signatures of a KRT.
+	SignatureDomain = "bundle-manifest"
+
+	// DefaultMaxSize is the max size of the bundle contents once uncompressed, unless set with WithMaxSize.
+	DefaultMaxSize int64 = 100 << 20
+)
+
+// Manifest describes the contents of a bundle.
+type Manifest struct {
+	// Root is the path of the KRT in the bundle, included files are relative to it.
+	Root  string         `yaml:"root"`
+	Files []ManifestFile `yaml:"files"`
+}
+
+// ManifestFile is a file of the bundle, the root KRT included.
+type ManifestFile struct {
+	Path   string `yaml:"path"`
+	SHA256 string `yaml:"sha256"`
+}
+
+// Canonical returns the serialization of the manifest signatures are computed over, its YAML with the files
+// sorted by path preceded by the canonical version. As the manifest lists the checksum of every file, a
+// signature of the manifest covers the whole bundle.
+func (m *Manifest) Canonical() ([]byte, error) {
+	manifestYaml, err := m.marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte("canonical: "+CanonicalVersion+"\n"), manifestYaml...), nil
+}
+
+// SignatureDomain returns the domain of manifest signatures, see signature.Signable.
+func (m *Manifest) SignatureDomain() string {
+	return SignatureDomain
+}
+
+// marshal returns the YAML of the manifest with the files sorted by path, as written in bundles.
+func (m *Manifest) marshal() ([]byte, error) {
+	sorted := Manifest{Root: m.Root, Files: append([]ManifestFile(nil), m.Files...)}
+	sort.Slice(sorted.Files, func(i, j int) bool { return sorted.Files[i].Path < sorted.Files[j].Path })
+
+	return yaml.Marshal(sorted)
+}
+
+// Bundle is a bundle read in memory, with its checksums verified.
+type Bundle struct {
+	Manifest Manifest
+	files    memFS
+}
+
+// FS returns the files of the bundle, so the root KRT can be parsed with its includes.
+func (b *Bundle) FS() fs.FS {
+	return b.files
+}
+
+// Option customizes how a bundle is read.
+type Option func(*options)
+
+type options struct {
+	maxSize int64
+}
+
+// WithMaxSize sets the max size in bytes of the bundle contents once uncompressed.
+func WithMaxSize(maxSize int64) Option {
+	return func(opts *options) {
+		opts.maxSize = maxSize
+	}
+}
+
+// PackDir writes a bundle with the files of the directory, as Pack does.
+func PackDir(w io.Writer, dir, root string, exclude ...string) (*Manifest, error) {
+	return Pack(w, os.DirFS(dir), root, exclude...)
+}
+
+// Pack writes a bundle with every file of the file system, root being the path of the KRT in it. Bundle
+// files, with the bundle extension, and the excluded paths are left out, so packing a directory does not
+// pack the bundles written in it.
+//
+// Hidden files and directories, such as .git, signature files and files holding a private key are left out
+// too, so the secrets kept next to a KRT are not shipped with it.
+//
+// Bundles are reproducible, packing the same files gives the same bytes.
+func Pack(w io.Writer, fsys fs.FS, root string, exclude ...string) (*Manifest, error) {
+	manifest := Manifest{Root: root}
+	files := make(map[string][]byte)
+
+	err := fs.WalkDir(fsys, ".", func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if path != "." && strings.HasPrefix(entry.Name(), ".") {
+			if entry.IsDir() {
+				return fs.SkipDir
+			}
+
+			return nil
+		}
+
+		if entry.IsDir() || isExcluded(path, exclude) {
+			return nil
+		}
+
+		if !entry.Type().IsRegular() {
+			return errors.InvalidBundleError(fmt.Sprintf("%s is not a regular file", path))
+		}
+
+		if path == ManifestPath {
+			return errors.InvalidBundleError(fmt.Sprintf("%s is reserved for the bundle manifest", path))
+		}
+
+		content, err := fs.ReadFile(fsys, path)
+		if err != nil {
+			return errors.ReadingFileError(err)
+		}
+
+		if isPrivateKey(content) {
+			return nil
+		}
+
+		files[path] = content
+		manifest.Files = append(manifest.Files, ManifestFile{Path: path, SHA256: checksum(content)})
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := files[root]; !ok {
+		return nil, errors.InvalidBundleError(fmt.Sprintf("root KRT %q not found", root))
+	}
+
+	sort.Slice(manifest.Files, func(i, j int) bool { return manifest.Files[i].Path < manifest.Files[j].Path })
+
+	manifestYaml, err := manifest.marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	gzipWriter := gzip.NewWriter(w)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	if err := writeFile(tarWriter, ManifestPath, manifestYaml); err != nil {
+		return nil, err
+	}
+
+	for _, file := range manifest.Files {
+		if err := writeFile(tarWriter, file.Path, files[file.Path]); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		return nil, err
+	}
+
+	if err := gzipWriter.Close(); err != nil {
+		return nil, err
+	}
+
+	return &manifest, nil
+}
+
+// isExcluded reports whether a file is left out of bundles, see Pack.
+func isExcluded(path string, exclude []string) bool {
+	return strings.HasSuffix(path, Extension) || strings.HasSuffix(path, signature.Extension) || slices.Contains(exclude, path)
+}
+
+// isPrivateKey reports whether the content holds a PEM encoded private key, of any type.
+func isPrivateKey(content []byte) bool {
+	rest := content
+
+	for {
+		block, remaining := pem.Decode(rest)
+		if block == nil {
+			return false
+		}
+
+		if strings.HasSuffix(block.Type, "PRIVATE KEY") {
+			return true
+		}
+
+		rest = remaining
+	}
+}
+
+func writeFile(tarWriter *tar.Writer, path string, content []byte) error {
+	header := &tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     path,
+		Mode:     0o644,
+		Size:     int64(len(content)),
+		ModTime:  time.Unix(0, 0),
+		Format:   tar.FormatPAX,
+	}
+
+	if err := tarWriter.WriteHeader(header); err != nil {
+		return err
+	}
+
+	_, err := tarWriter.Write(content)
+
+	return err
+}
+
+// Read reads a bundle in memory, verifying its checksums.
+//
+// Only regular files and directories are accepted, with paths that cannot escape the bundle.
+func Read(r io.Reader, opts ...Option) (*Bundle, error) {
+	bundleOptions := options{maxSize: DefaultMaxSize}
+	for _, opt := range opts {
+		opt(&bundleOptions)
+	}
+
+	gzipReader, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, errors.InvalidBundleError(err.Error())
+	}
+
+	var (
+		files     = make(memFS)
+		size      int64
+		tarReader = tar.NewReader(gzipReader)
+	)
+
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, errors.InvalidBundleError(err.Error())
+		}
+
+		path := strings.TrimPrefix(header.Name, "./")
+
+		if header.Typeflag == tar.TypeDir {
+			continue
+		}
+
+		if !isSafePath(path) {
+			return nil, errors.UnsafeBundlePathError(header.Name)
+		}
+
+		if header.Typeflag != tar.TypeReg {
+			return nil, errors.InvalidBundleError(fmt.Sprintf("%s is not a regular file", header.Name))
+		}
+
+		if _, ok := files[path]; ok {
+			return nil, errors.InvalidBundleError(fmt.Sprintf("%s is in the bundle more than once", path))
+		}
+
+		content, err := io.ReadAll(io.LimitReader(tarReader, bundleOptions.maxSize-size+1))
+		if err != nil {
+			return nil, errors.InvalidBundleError(err.Error())
+		}
+
+		if size += int64(len(content)); size > bundleOptions.maxSize {
+			return nil, errors.FileTooLargeError(bundleOptions.maxSize)
+		}
+
+		files[path] = content
+	}
+
+	manifest, err := verify(files)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Bundle{Manifest: *manifest, files: files}, nil
+}
+
+// Unpack writes the files of a bundle in the directory, once its checksums are verified. Existing files
+// are not overwritten, nothing is written if any file of the bundle already exists in the directory, or
+// if a directory of the bundle exists as a symlink or a file, as files could land outside the directory.
+// The files and directories written are removed if unpacking fails halfway.
+func Unpack(r io.Reader, dir string, opts ...Option) (*Manifest, error) {
+	bundle, err := Read(r, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, path := range bundle.files.paths() {
+		if err := checkTarget(dir, path); err != nil {
+			return nil, err
+		}
+	}
+
+	var created []string
+
+	if _, err := os.Lstat(dir); errors.Is(err, fs.ErrNotExist) {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, err
+		}
+
+		created = append(created, dir)
+	}
+
+	for _, path := range bundle.files.paths() {
+		if err := unpackFile(dir, path, bundle.files[path], &created); err != nil {
+			removeCreated(created)
+			return nil, err
+		}
+	}
+
+	return &bundle.Manifest, nil
+}
+
+// checkTarget checks a file of the bundle can be unpacked in the directory: the file must not exist, and its
+// parent directories inside the directory must either not exist or be directories.
+func checkTarget(dir, path string) error {
+	target := dir
+	parts := strings.Split(path, "/")
+
+	for idx, part := range parts {
+		target = filepath.Join(target, part)
+
+		info, err := os.Lstat(target)
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if idx == len(parts)-1 {
+			return &fs.PathError{Op: "unpack", Path: target, Err: fs.ErrExist}
+		}
+
+		if !info.IsDir() {
+			return errors.UnsafeUnpackPathError(path, target)
+		}
+	}
+
+	return nil
+}
+
+// unpackFile writes a file of the bundle in the directory, along with its missing parent directories,
+// adding every path it creates to created.
+func unpackFile(dir, path string, content []byte, created *[]string) error {
+	target := dir
+	parts := strings.Split(path, "/")
+
+	for _, part := range parts[:len(parts)-1] {
+		target = filepath.Join(target, part)
+
+		err := os.Mkdir(target, 0o755)
+		if err == nil {
+			*created = append(*created, target)
+			continue
+		}
+
+		if !errors.Is(err, fs.ErrExist) {
+			return err
+		}
+
+		// checked again, as the directory could have been replaced since checkTarget
+		if info, err := os.Lstat(target); err != nil || !info.IsDir() {
+			return errors.UnsafeUnpackPathError(path, target)
+		}
+	}
+
+	target = filepath.Join(target, parts[len(parts)-1])
+
+	file, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return err
+	}
+
+	*created = append(*created, target)
+
+	_, err = file.Write(content)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+
+	return err
+}
+
+// removeCreated removes the given files and directories, in the reverse order they were created.
+func removeCreated(created []string) {
+	for idx := len(created) - 1; idx >= 0; idx-- {
+		_ = os.Remove(created[idx])
+	}
+}
+
+// Verify checks the files of an unpacked bundle against the checksums of its manifest, failing if
+// a file is missing, modified or not listed in the manifest.
+func Verify(fsys fs.FS) (*Manifest, error) {
+	files := make(memFS)
+
+	err := fs.WalkDir(fsys, ".", func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+
+		content, err := fs.ReadFile(fsys, path)
+		if err != nil {
+			return errors.ReadingFileError(err)
+		}
+
+		files[path] = content
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return verify(files)
+}
+
+// verify checks the files of a bundle against the checksums of its manifest, returning the manifest.
+func verify(files memFS) (*Manifest, error) {
+	manifestYaml, ok := files[ManifestPath]
+	if !ok {
+		return nil, errors.InvalidBundleError("missing " + ManifestPath)
+	}
+
+	var manifest Manifest
+
+	if err := yaml.Unmarshal(manifestYaml, &manifest); err != nil {
+		return nil, errors.InvalidBundleError(fmt.Sprintf("invalid %s: %s", ManifestPath, err))
+	}
+
+	var totalError error
+
+	listed := make(map[string]bool, len(manifest.Files))
+
+	for _, file := range manifest.Files {
+		listed[file.Path] = true
+
+		content, ok := files[file.Path]
+		if !ok {
+			totalError = errors.Join(totalError, errors.InvalidBundleError(fmt.Sprintf("%s is missing", file.Path)))
+			continue
+		}
+
+		if actual := checksum(content); actual != file.SHA256 {
+			totalError = errors.Join(totalError, errors.ChecksumMismatchError(file.Path, file.SHA256, actual))
+		}
+	}
+
+	for _, path := range files.paths() {
+		if !listed[path] && path != ManifestPath {
+			totalError = errors.Join(totalError, errors.InvalidBundleError(fmt.Sprintf("%s is not listed in the manifest", path)))
+		}
+	}
+
+	if !listed[manifest.Root] {
+		totalError = errors.Join(totalError, errors.InvalidBundleError(fmt.Sprintf("root KRT %q not found", manifest.Root)))
+	}
+
+	if totalError != nil {
+		return nil, totalError
+	}
+
+	return &manifest, nil
+}
+
+func checksum(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// isSafePath reports whether the path is relative and stays inside the bundle once unpacked.
+func isSafePath(path string) bool {
+	return fs.ValidPath(path) && path != "." && !strings.ContainsAny(path, "\\\x00")
+}
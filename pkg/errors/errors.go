@@ -16,16 +16,70 @@ func Is(err, target error) bool {
 // Validation errors.
 
 var ErrMissingRequiredField = errors.New("missing required field")
-var ErrInvalidVersionTag = errors.New("invalid version tag; must follow the format 'vX.Y.Z'")
+var ErrInvalidVersionTag = errors.New(
+	"invalid version tag; must be a semantic version such as 'v1.2.3', 'v1.2.3-rc.1' or 'v1.2.3+build.5'",
+)
+var ErrVersionPrereleaseNotAllowed = errors.New(
+	"invalid version tag; prerelease and build identifiers are not allowed by the version policy",
+)
+var ErrUnknownVersionPolicy = errors.New("unknown version policy")
+var ErrUnsupportedAPIVersion = errors.New("unsupported api version")
 var ErrInvalidFieldName = errors.New("invalid field name; only numbers, hyphens and lowercase letters are allowed")
 var ErrInvalidLengthField = errors.New("field length is higher than the maximum")
+var ErrInvalidDuration = errors.New("invalid duration, must be a positive duration such as '30s' or '5m'")
+var ErrValueMustBePositive = errors.New("invalid value, must be greater than zero")
+var ErrValueMustNotBeNegative = errors.New("invalid value, cannot be lower than zero")
+var ErrInvalidConfigKey = errors.New(
+	"invalid config key; only letters, numbers and underscores are allowed, and it cannot start with a number",
+)
+var ErrReservedConfigKey = errors.New("invalid config key; it starts with a prefix reserved by the runtime")
+var ErrConflictingConfigKey = errors.New("conflicting config key; keys cannot differ only in case")
 
 var ErrDuplicatedWorkflowName = errors.New("workflow names must be unique")
 var ErrInvalidWorkflowType = errors.New("invalid workflow type, must be either 'data', 'training' 'feedback' or 'serving'")
+var ErrGPUNotAllowedInWorkflow = errors.New("invalid gpu, the workflow type does not allow GPUs")
+var ErrMissingNetworkedTrigger = errors.New("the workflow type requires a trigger process with networking")
+var ErrMissingCrossWorkflowSubscription = errors.New("the workflow type requires a subscription to another workflow")
 
 var ErrInvalidProcessType = errors.New("invalid process type, must be either 'trigger', 'task' or 'exit'")
 var ErrInvalidProcessObjectStoreScope = errors.New("invalid process object store scope, must be either 'product' or 'workflow'")
-var ErrInvalidNetworkingProtocol = errors.New("invalid networking protocol, must be either 'HTTP' or 'GRPC'")
+var ErrInvalidProcessMode = errors.New("invalid process mode, must be either 'service' or 'job'")
+var ErrUnexpectedJobConfig = errors.New("invalid job settings, only processes with mode 'job' can declare them")
+var ErrJobNotAllowed = errors.New("invalid process mode, only task processes can run as jobs")
+var ErrJobNotAllowedInWorkflow = errors.New("invalid process mode, the workflow type does not allow jobs")
+var ErrJobReplicas = errors.New("invalid replicas, jobs run a single replica, use 'job.parallelism' instead")
+var ErrInvalidJobSubscriptions = errors.New("invalid job subscriptions, a job is started by a single subscription")
+var ErrInvalidNetworkingProtocol = errors.New(
+	"invalid networking protocol, must be a registered protocol such as 'HTTP', 'GRPC', 'TCP' or 'WEBSOCKET'",
+)
+var ErrInvalidPort = errors.New("invalid port, must be between 1 and 65535")
+var ErrPrivilegedPort = errors.New("invalid port, privileged ports lower than 1024 are not allowed")
+var ErrConflictingNetworkingPorts = errors.New(
+	"invalid networking, ports must be declared either as a list or with 'targetPort', 'destinationPort' and 'protocol', not both",
+)
+var ErrDuplicatedPortName = errors.New("port names must be unique")
+var ErrDuplicatedTargetPort = errors.New("target ports must be unique inside a process")
+var ErrDuplicatedDestinationPort = errors.New("destination ports must be unique inside a workflow")
+var ErrIngressNotAllowed = errors.New("invalid ingress, only trigger processes can be exposed")
+var ErrInvalidIngressPathPrefix = errors.New("invalid ingress path prefix, must start with '/'")
+var ErrInvalidIngressVisibility = errors.New("invalid ingress visibility, must be either 'public' or 'internal'")
+var ErrIngressPortNotFound = errors.New("invalid ingress port, it must be one of the process networking ports")
+var ErrDuplicatedIngressRoute = errors.New("ingress host and path prefix must be unique")
+var ErrTriggerNotAllowed = errors.New("invalid trigger, only trigger processes can declare a trigger")
+var ErrInvalidTriggerKind = errors.New(
+	"invalid trigger kind, must be either 'grpc', 'http', 'cron', 'object-store' or 'message-queue'",
+)
+var ErrUnexpectedTriggerConfig = errors.New("invalid trigger, configuration does not apply to the trigger kind")
+var ErrTriggerProtocolMismatch = errors.New("invalid trigger, no networking port uses the protocol required by the trigger kind")
+var ErrInvalidCronExpression = errors.New("invalid cron expression")
+var ErrInvalidTimezone = errors.New("invalid timezone, must be an IANA time zone name such as 'Europe/Madrid'")
+var ErrInvalidObjectStoreEvent = errors.New("invalid object store event, must be either 'created' or 'deleted'")
+var ErrInvalidMessageQueueSubject = errors.New(
+	"invalid message queue subject, only letters, numbers, hyphens and underscores separated by dots are allowed",
+)
+var ErrRetryNotAllowed = errors.New("invalid retry policy, trigger processes cannot be retried")
+var ErrInvalidBackoff = errors.New("invalid retry backoff")
+var ErrTimeoutExceedsTrigger = errors.New("invalid timeout, exit processes cannot wait longer than the triggers of the workflow")
 var ErrInvalidProcessCPUResourceLimit = errors.New("invalid process CPU resource limit, must be of form '1', '0.5' or '100m'")
 var ErrInvalidProcessCPURelation = errors.New("invalid process CPU, 'limit' cannot be lower than 'request'")
 var ErrInvalidProcessMemoryResourceLimit = errors.New("invalid process memory resource limit, must be of form '350M' or '1Gi'")
@@ -37,6 +91,20 @@ var ErrDuplicatedProcessSubscription = errors.New("subscriptions cannot be dupli
 var ErrInvalidProcessSubscription = errors.New("invalid subscription")
 var ErrCannotSubscribeToItself = errors.New("cannot subscribe to itself")
 var ErrCannotSubscribeToNonExistentProcess = errors.New("cannot subscribe to non existent process")
+var ErrCannotSubscribeToNonExistentWorkflow = errors.New("cannot subscribe to non existent workflow")
+var ErrInvalidCrossWorkflowSubscription = errors.New("invalid cross workflow subscription")
+var ErrCannotSubscribeToNonExistentSubtopic = errors.New("cannot subscribe to non existent subtopic")
+var ErrDeadLetterNonExistentProcess = errors.New("dead-letter target process does not exist")
+var ErrInvalidDeadLetter = errors.New("invalid dead-letter target")
+var ErrUnknownSubscriptionSettings = errors.New("subscription settings for a subscription the process does not declare")
+var ErrInvalidJoin = errors.New("invalid join")
+var ErrInvalidJoinMode = errors.New("invalid join mode, must be either 'any-of' or 'all-of'")
+var ErrDuplicatedSubtopic = errors.New("subtopics cannot be duplicated")
+var ErrUnusedSubtopic = errors.New("subtopic is declared but no process subscribes to it")
+
+var ErrProcessTemplateNotFound = errors.New("process template not found")
+var ErrProcessTemplateCycle = errors.New("process templates extend each other in a cycle")
+var ErrResourceProfileNotFound = errors.New("resource profile not found")
 
 func errorWithMessage(err error, message string) error {
 	return fmt.Errorf("%w: %s", err, message)
@@ -50,6 +118,22 @@ func InvalidVersionTagError(field string) error {
 	return errorWithMessage(ErrInvalidVersionTag, field)
 }
 
+func InvalidVersionError(version string) error {
+	return fmt.Errorf("%w: %q", ErrInvalidVersionTag, version)
+}
+
+func VersionPrereleaseNotAllowedError(field string) error {
+	return errorWithMessage(ErrVersionPrereleaseNotAllowed, field)
+}
+
+func UnknownVersionPolicyError(policy string) error {
+	return fmt.Errorf("%w: %q", ErrUnknownVersionPolicy, policy)
+}
+
+func UnsupportedAPIVersionError(apiVersion, field string) error {
+	return fmt.Errorf("%w: %q in %s", ErrUnsupportedAPIVersion, apiVersion, field)
+}
+
 func InvalidFieldNameError(field string) error {
 	return errorWithMessage(ErrInvalidFieldName, field)
 }
@@ -58,6 +142,30 @@ func InvalidLengthFieldError(field string, maxLength int) error {
 	return fmt.Errorf("%w: %s; maximum length allowed: %d", ErrInvalidLengthField, field, maxLength)
 }
 
+func InvalidDurationError(field string) error {
+	return errorWithMessage(ErrInvalidDuration, field)
+}
+
+func ValueMustBePositiveError(field string) error {
+	return errorWithMessage(ErrValueMustBePositive, field)
+}
+
+func ValueMustNotBeNegativeError(field string) error {
+	return errorWithMessage(ErrValueMustNotBeNegative, field)
+}
+
+func InvalidConfigKeyError(field string) error {
+	return errorWithMessage(ErrInvalidConfigKey, field)
+}
+
+func ReservedConfigKeyError(field string) error {
+	return errorWithMessage(ErrReservedConfigKey, field)
+}
+
+func ConflictingConfigKeyError(field, conflictingField string) error {
+	return fmt.Errorf("%w: %s conflicts with %s", ErrConflictingConfigKey, field, conflictingField)
+}
+
 func DuplicatedWorkflowNameError(field string) error {
 	return errorWithMessage(ErrDuplicatedWorkflowName, field)
 }
@@ -78,6 +186,130 @@ func InvalidNetworkingProtocolError(field string) error {
 	return errorWithMessage(ErrInvalidNetworkingProtocol, field)
 }
 
+func InvalidPortError(field string) error {
+	return errorWithMessage(ErrInvalidPort, field)
+}
+
+func PrivilegedPortError(field string) error {
+	return errorWithMessage(ErrPrivilegedPort, field)
+}
+
+func ConflictingNetworkingPortsError(field string) error {
+	return errorWithMessage(ErrConflictingNetworkingPorts, field)
+}
+
+func DuplicatedPortNameError(field string) error {
+	return errorWithMessage(ErrDuplicatedPortName, field)
+}
+
+func DuplicatedTargetPortError(field string) error {
+	return errorWithMessage(ErrDuplicatedTargetPort, field)
+}
+
+func DuplicatedDestinationPortError(field, previousField string) error {
+	return fmt.Errorf("%w: %s is already used in %s", ErrDuplicatedDestinationPort, field, previousField)
+}
+
+func IngressNotAllowedError(processType, field string) error {
+	return fmt.Errorf("%w: process of type %q in %s", ErrIngressNotAllowed, processType, field)
+}
+
+func InvalidIngressPathPrefixError(field string) error {
+	return errorWithMessage(ErrInvalidIngressPathPrefix, field)
+}
+
+func InvalidIngressVisibilityError(field string) error {
+	return errorWithMessage(ErrInvalidIngressVisibility, field)
+}
+
+func IngressPortNotFoundError(port, field string) error {
+	return fmt.Errorf("%w: port named %q does not exist %s", ErrIngressPortNotFound, port, field)
+}
+
+func DuplicatedIngressRouteError(route, field, previousField string) error {
+	return fmt.Errorf("%w: route %q in %s is already used in %s", ErrDuplicatedIngressRoute, route, field, previousField)
+}
+
+func TriggerNotAllowedError(processType, field string) error {
+	return fmt.Errorf("%w: process of type %q in %s", ErrTriggerNotAllowed, processType, field)
+}
+
+func InvalidTriggerKindError(field string) error {
+	return errorWithMessage(ErrInvalidTriggerKind, field)
+}
+
+func UnexpectedTriggerConfigError(kind, field string) error {
+	return fmt.Errorf("%w: %s cannot be declared in a %q trigger", ErrUnexpectedTriggerConfig, field, kind)
+}
+
+func TriggerProtocolMismatchError(kind, protocol, field string) error {
+	return fmt.Errorf("%w: a %q trigger needs a %q port, in %s", ErrTriggerProtocolMismatch, kind, protocol, field)
+}
+
+func InvalidCronExpressionError(field string, err error) error {
+	return &causeError{kind: ErrInvalidCronExpression, msg: fmt.Sprintf("%s: %s: %s", ErrInvalidCronExpression, field, err), cause: err}
+}
+
+func InvalidTimezoneError(timezone, field string) error {
+	return fmt.Errorf("%w: %q in %s", ErrInvalidTimezone, timezone, field)
+}
+
+func InvalidObjectStoreEventError(field string) error {
+	return errorWithMessage(ErrInvalidObjectStoreEvent, field)
+}
+
+func InvalidMessageQueueSubjectError(field string) error {
+	return errorWithMessage(ErrInvalidMessageQueueSubject, field)
+}
+
+func GPUNotAllowedInWorkflowError(workflowType, field string) error {
+	return fmt.Errorf("%w: workflow of type %q in %s", ErrGPUNotAllowedInWorkflow, workflowType, field)
+}
+
+func MissingNetworkedTriggerError(workflowType, field string) error {
+	return fmt.Errorf("%w: workflow of type %q in %s", ErrMissingNetworkedTrigger, workflowType, field)
+}
+
+func MissingCrossWorkflowSubscriptionError(workflowType, field string) error {
+	return fmt.Errorf("%w: workflow of type %q in %s", ErrMissingCrossWorkflowSubscription, workflowType, field)
+}
+
+func InvalidProcessModeError(field string) error {
+	return errorWithMessage(ErrInvalidProcessMode, field)
+}
+
+func UnexpectedJobConfigError(field string) error {
+	return errorWithMessage(ErrUnexpectedJobConfig, field)
+}
+
+func JobNotAllowedError(processType, field string) error {
+	return fmt.Errorf("%w: process of type %q in %s", ErrJobNotAllowed, processType, field)
+}
+
+func JobNotAllowedInWorkflowError(workflowType, field string) error {
+	return fmt.Errorf("%w: workflow of type %q in %s", ErrJobNotAllowedInWorkflow, workflowType, field)
+}
+
+func JobReplicasError(field string) error {
+	return errorWithMessage(ErrJobReplicas, field)
+}
+
+func InvalidJobSubscriptionsError(field string) error {
+	return errorWithMessage(ErrInvalidJobSubscriptions, field)
+}
+
+func RetryNotAllowedError(processType, field string) error {
+	return fmt.Errorf("%w: process of type %q in %s", ErrRetryNotAllowed, processType, field)
+}
+
+func InvalidBackoffError(reason, field string) error {
+	return fmt.Errorf("%w: %s, in %s", ErrInvalidBackoff, reason, field)
+}
+
+func TimeoutExceedsTriggerError(trigger, field, triggerField string) error {
+	return fmt.Errorf("%w: %s exceeds the timeout of trigger %q in %s", ErrTimeoutExceedsTrigger, field, trigger, triggerField)
+}
+
 func InvalidProcessCPUError(field string) error {
 	return errorWithMessage(ErrInvalidProcessCPUResourceLimit, field)
 }
@@ -124,10 +356,150 @@ func CannotSubscribeToNonExistentProcessError(process, field string) error {
 	return fmt.Errorf("%w: process named %q does not exist %s", ErrCannotSubscribeToNonExistentProcess, process, field)
 }
 
+func CannotSubscribeToNonExistentWorkflowError(workflow, field string) error {
+	return fmt.Errorf("%w: workflow named %q does not exist, in %s", ErrCannotSubscribeToNonExistentWorkflow, workflow, field)
+}
+
+func InvalidCrossWorkflowSubscriptionError(workflowType, subscribedWorkflowType, field string) error {
+	return fmt.Errorf(
+		"%w: a workflow of type %q cannot subscribe to %q workflows, in %s",
+		ErrInvalidCrossWorkflowSubscription,
+		workflowType,
+		subscribedWorkflowType,
+		field,
+	)
+}
+
+func CannotSubscribeToNonExistentSubtopicError(subtopic, process, closestSubtopic, field string) error {
+	if closestSubtopic == "" {
+		return fmt.Errorf(
+			"%w: subtopic %q, process %q does not declare any subtopic, in %s",
+			ErrCannotSubscribeToNonExistentSubtopic, subtopic, process, field,
+		)
+	}
+
+	return fmt.Errorf(
+		"%w: subtopic %q is not declared by process %q, did you mean %q?, in %s",
+		ErrCannotSubscribeToNonExistentSubtopic, subtopic, process, closestSubtopic, field,
+	)
+}
+
+func DeadLetterNonExistentProcessError(process, field string) error {
+	return fmt.Errorf("%w: process named %q does not exist, in %s", ErrDeadLetterNonExistentProcess, process, field)
+}
+
+func InvalidDeadLetterError(reason, field string) error {
+	return fmt.Errorf("%w: %s, in %s", ErrInvalidDeadLetter, reason, field)
+}
+
+func UnknownSubscriptionSettingsError(field string) error {
+	return errorWithMessage(ErrUnknownSubscriptionSettings, field)
+}
+
+func ProcessTemplateNotFoundError(template string) error {
+	return fmt.Errorf("%w: %q", ErrProcessTemplateNotFound, template)
+}
+
+func ProcessTemplateCycleError(chain string) error {
+	return fmt.Errorf("%w: %s", ErrProcessTemplateCycle, chain)
+}
+
+func ResourceProfileNotFoundError(profile, field string) error {
+	return fmt.Errorf("%w: %q, in %s", ErrResourceProfileNotFound, profile, field)
+}
+
+// ExpandedProcessError adds the template a process extends to each validation error of the expanded process,
+// as the invalid field may come from the template.
+func ExpandedProcessError(err error, template string) error {
+	return wrapEach(err, fmt.Sprintf(", in a process extending template %q", template))
+}
+
+// IncludedWorkflowError adds the file a workflow was included from to each of its validation errors.
+func IncludedWorkflowError(err error, file string) error {
+	return wrapEach(err, fmt.Sprintf(", in workflow included from %q", file))
+}
+
+// wrapEach adds the context to the message of each joined error. Every error wrapping several errors is split,
+// so validation errors with a cause of their own wrap it with a causeError instead.
+func wrapEach(err error, context string) error {
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		return fmt.Errorf("%w%s", err, context)
+	}
+
+	var totalError error
+	for _, err := range joined.Unwrap() {
+		totalError = Join(totalError, wrapEach(err, context))
+	}
+
+	return totalError
+}
+
+// causeError is an error of a given kind caused by another error, matching both with Is.
+type causeError struct {
+	kind  error
+	msg   string
+	cause error
+}
+
+func (e *causeError) Error() string {
+	return e.msg
+}
+
+func (e *causeError) Unwrap() error {
+	return e.cause
+}
+
+func (e *causeError) Is(target error) bool {
+	return target == e.kind
+}
+
+func InvalidJoinError(reason, field string) error {
+	return fmt.Errorf("%w: %s, in %s", ErrInvalidJoin, reason, field)
+}
+
+func InvalidJoinModeError(field string) error {
+	return errorWithMessage(ErrInvalidJoinMode, field)
+}
+
+func DuplicatedSubtopicError(field string) error {
+	return errorWithMessage(ErrDuplicatedSubtopic, field)
+}
+
+func UnusedSubtopicError(field string) error {
+	return errorWithMessage(ErrUnusedSubtopic, field)
+}
+
+// Lookup errors.
+
+var ErrWorkflowNotFound = errors.New("workflow not found")
+var ErrProcessNotFound = errors.New("process not found")
+
+func WorkflowNotFoundError(workflow string) error {
+	return fmt.Errorf("%w: %q", ErrWorkflowNotFound, workflow)
+}
+
+func ProcessNotFoundError(workflow, process string) error {
+	return fmt.Errorf("%w: %q in workflow %q", ErrProcessNotFound, process, workflow)
+}
+
 // Parse errors.
 
 var ErrInvalidYaml = errors.New("invalid yaml")
 var ErrReadingFile = errors.New("error reading file")
+var ErrFileTooLarge = errors.New("file too large")
+var ErrDeprecatedField = errors.New("deprecated field")
+var ErrInvalidOverlay = errors.New("invalid overlay")
+var ErrOverlayConflict = errors.New("conflicting overlays")
+var ErrOverlayReferenceNotFound = errors.New("overlay patches a workflow or process that does not exist")
+var ErrInvalidInclude = errors.New("invalid include")
+var ErrIncludeCycle = errors.New("files include each other in a cycle")
+var ErrIncludeOutsideRoot = errors.New("included file is outside the directory of the root file")
+var ErrInvalidTemplateParameter = errors.New("invalid template parameter")
+var ErrUndeclaredTemplateParameter = errors.New("undeclared template parameter")
+var ErrMissingTemplateParameter = errors.New("missing value for required template parameter")
+var ErrInvalidTemplateValue = errors.New("invalid value for template parameter")
+var ErrUnknownTemplateValue = errors.New("value for an undeclared template parameter")
 
 func InvalidYamlError(err error) error {
 	return fmt.Errorf("error unmarshalling krt yaml, %w: %w", ErrInvalidYaml, err)
@@ -136,3 +508,100 @@ func InvalidYamlError(err error) error {
 func ReadingFileError(err error) error {
 	return fmt.Errorf("%w: %w", ErrReadingFile, err)
 }
+
+func FileTooLargeError(maxSize int64) error {
+	return fmt.Errorf("%w: the max size is %d bytes", ErrFileTooLarge, maxSize)
+}
+
+func InvalidOverlayError(overlay, reason string) error {
+	return fmt.Errorf("%s: %w: %s", overlay, ErrInvalidOverlay, reason)
+}
+
+func OverlayConflictError(field, overlay, previousOverlay string) error {
+	return fmt.Errorf("%s: %w: %s is already set to a different value in %s", overlay, ErrOverlayConflict, field, previousOverlay)
+}
+
+func OverlayReferenceNotFoundError(overlay, field string) error {
+	return fmt.Errorf("%s: %w: %s", overlay, ErrOverlayReferenceNotFound, field)
+}
+
+func InvalidIncludeError(file, reason string) error {
+	return fmt.Errorf("%s: %w: %s", file, ErrInvalidInclude, reason)
+}
+
+func IncludeCycleError(chain string) error {
+	return fmt.Errorf("%w: %s", ErrIncludeCycle, chain)
+}
+
+func IncludeOutsideRootError(include, file string) error {
+	return fmt.Errorf("%s: %w: %s", file, ErrIncludeOutsideRoot, include)
+}
+
+func InvalidTemplateParameterError(parameter, reason, field string) error {
+	return fmt.Errorf("%w %q: %s, in %s", ErrInvalidTemplateParameter, parameter, reason, field)
+}
+
+func UndeclaredTemplateParameterError(parameter, field string) error {
+	return fmt.Errorf("%w %q, in %s", ErrUndeclaredTemplateParameter, parameter, field)
+}
+
+func MissingTemplateParameterError(parameter, field string) error {
+	return fmt.Errorf("%w %q, in %s", ErrMissingTemplateParameter, parameter, field)
+}
+
+func InvalidTemplateValueError(parameter, reason, field string) error {
+	return fmt.Errorf("%w %q: %s, in %s", ErrInvalidTemplateValue, parameter, reason, field)
+}
+
+func UnknownTemplateValueError(parameter string) error {
+	return fmt.Errorf("%w: %q", ErrUnknownTemplateValue, parameter)
+}
+
+func DeprecatedFieldError(field, replacement, removedIn string) error {
+	return fmt.Errorf("%w: %s will be removed in %s, use %s instead", ErrDeprecatedField, field, removedIn, replacement)
+}
+
+// Bundle errors.
+
+var ErrInvalidBundle = errors.New("invalid bundle")
+var ErrUnsafeBundlePath = errors.New("bundle path escapes the bundle")
+var ErrChecksumMismatch = errors.New("checksum mismatch")
+
+func InvalidBundleError(reason string) error {
+	return fmt.Errorf("%w: %s", ErrInvalidBundle, reason)
+}
+
+func UnsafeBundlePathError(path string) error {
+	return fmt.Errorf("%w: %s", ErrUnsafeBundlePath, path)
+}
+
+func UnsafeUnpackPathError(path, parent string) error {
+	return fmt.Errorf("%w: %s, %s is not a directory", ErrUnsafeBundlePath, path, parent)
+}
+
+func ChecksumMismatchError(path, expected, actual string) error {
+	return fmt.Errorf("%w: %s has checksum %s, expected %s", ErrChecksumMismatch, path, actual, expected)
+}
+
+// Signature errors.
+
+var ErrInvalidSignature = errors.New("invalid signature")
+var ErrUntrustedKey = errors.New("signed with an untrusted key")
+var ErrMalformedSignature = errors.New("malformed signature")
+var ErrInvalidKey = errors.New("invalid key")
+
+func InvalidSignatureError(keyID string) error {
+	return fmt.Errorf("%w: the content does not match the signature of key %s", ErrInvalidSignature, keyID)
+}
+
+func UntrustedKeyError(keyID string) error {
+	return fmt.Errorf("%w: %s", ErrUntrustedKey, keyID)
+}
+
+func MalformedSignatureError(reason string) error {
+	return fmt.Errorf("%w: %s", ErrMalformedSignature, reason)
+}
+
+func InvalidKeyError(reason string) error {
+	return fmt.Errorf("%w: %s", ErrInvalidKey, reason)
+}
@@ -0,0 +1,171 @@
+package krt
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/creasty/defaults"
+	"gopkg.in/yaml.v3"
+)
+
+// CanonicalVersion is the version of the canonical serialization, written at its start. It changes whenever
+// the serialization does, so a signature or hash computed over another version is not silently compared.
+const CanonicalVersion = "v1"
+
+// SignatureDomain is the domain of KRT signatures, so they cannot be taken for signatures of other content.
+const SignatureDomain = "krt"
+
+// Canonical returns the serialization of the KRT signatures and fingerprints are computed over.
+//
+// It is the YAML of the normalized KRT, with fields in declaration order and map keys sorted, so it only
+// depends on the content of the KRT: neither on the formatting, comments or key order of the file it was
+// parsed from, nor on whether it was validated, which completes the resource limits. Empty fields and fields
+// set to their default value are left out, so adding an optional field to the KRT does not change it.
+func (krt *Krt) Canonical() ([]byte, error) {
+	canonical, err := krt.canonical()
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalCanonical(canonical)
+}
+
+// SignatureDomain returns the domain of KRT signatures, see signature.Signable.
+func (krt *Krt) SignatureDomain() string {
+	return SignatureDomain
+}
+
+// canonical returns a normalized copy of the KRT with defaults applied, see Canonical.
+func (krt *Krt) canonical() (*Krt, error) {
+	normalized, err := krt.Normalize()
+	if err != nil {
+		return nil, err
+	}
+
+	defaults.MustSet(normalized)
+
+	// validation sets missing limits to the request, they are set here so the result is the same
+	normalized.completeResourceLimits()
+
+	return normalized, nil
+}
+
+// marshalCanonical returns the canonical serialization of a value, preceded by the canonical version.
+func marshalCanonical(value any) ([]byte, error) {
+	node, err := canonicalNode(reflect.ValueOf(value))
+	if err != nil {
+		return nil, err
+	}
+
+	canonicalYaml := []byte("canonical: " + CanonicalVersion + "\n")
+	if node == nil {
+		return canonicalYaml, nil
+	}
+
+	content, err := yaml.Marshal(node)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(canonicalYaml, content...), nil
+}
+
+// canonicalNode returns the YAML node of a value without its empty or default valued fields, nil if the
+// value itself is empty. Maps are kept as they are, as their entries are not fields but user data.
+func canonicalNode(value reflect.Value) (*yaml.Node, error) {
+	if value.Kind() != reflect.Pointer && value.Type().Implements(reflect.TypeOf((*yaml.Marshaler)(nil)).Elem()) {
+		return encodeNode(value)
+	}
+
+	switch value.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if value.IsNil() {
+			return nil, nil
+		}
+
+		return canonicalNode(value.Elem())
+	case reflect.Struct:
+		return canonicalStructNode(value)
+	case reflect.Slice:
+		if value.Len() == 0 {
+			return nil, nil
+		}
+
+		node := &yaml.Node{Kind: yaml.SequenceNode}
+
+		for idx := 0; idx < value.Len(); idx++ {
+			item, err := canonicalNode(value.Index(idx))
+			if err != nil {
+				return nil, err
+			}
+
+			if item == nil {
+				item = &yaml.Node{Kind: yaml.MappingNode}
+			}
+
+			node.Content = append(node.Content, item)
+		}
+
+		return node, nil
+	case reflect.Map:
+		if value.Len() == 0 {
+			return nil, nil
+		}
+
+		return encodeNode(value)
+	default:
+		if value.IsZero() {
+			return nil, nil
+		}
+
+		return encodeNode(value)
+	}
+}
+
+// canonicalStructNode returns the mapping of the fields of a struct that are neither empty nor set to the
+// value the struct has by default.
+func canonicalStructNode(value reflect.Value) (*yaml.Node, error) {
+	defaulted := reflect.New(value.Type())
+	defaults.MustSet(defaulted.Interface())
+
+	node := &yaml.Node{Kind: yaml.MappingNode}
+
+	for idx := 0; idx < value.NumField(); idx++ {
+		field := value.Type().Field(idx)
+
+		name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if !field.IsExported() || name == "-" || reflect.DeepEqual(value.Field(idx).Interface(), defaulted.Elem().Field(idx).Interface()) {
+			continue
+		}
+
+		fieldNode, err := canonicalNode(value.Field(idx))
+		if err != nil {
+			return nil, err
+		}
+
+		if fieldNode == nil {
+			continue
+		}
+
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+
+		node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: name}, fieldNode)
+	}
+
+	if len(node.Content) == 0 {
+		return nil, nil
+	}
+
+	return node, nil
+}
+
+func encodeNode(value reflect.Value) (*yaml.Node, error) {
+	var node yaml.Node
+	if err := node.Encode(value.Interface()); err != nil {
+		return nil, err
+	}
+
+	return &node, nil
+}
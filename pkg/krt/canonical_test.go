@@ -0,0 +1,120 @@
+//go:build unit
+
+package krt_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/konstellation-io/krt/pkg/krt"
+)
+
+func TestKrt_Canonical(t *testing.T) {
+	canonical, err := NewKrtBuilder().Build().Canonical()
+	require.NoError(t, err)
+
+	t.Run("is stable", func(t *testing.T) {
+		again, err := NewKrtBuilder().Build().Canonical()
+		require.NoError(t, err)
+		assert.Equal(t, canonical, again)
+	})
+
+	t.Run("does not depend on validation", func(t *testing.T) {
+		krtYaml := NewKrtBuilder().
+			WithProcessResourceLimits(&krt.ProcessResourceLimits{
+				CPU:    &krt.ResourceLimit{Request: "100m"},
+				Memory: &krt.ResourceLimit{Request: "100M"},
+			}, 0).
+			Build()
+
+		before, err := krtYaml.Canonical()
+		require.NoError(t, err)
+		require.NoError(t, krtYaml.Validate())
+
+		after, err := krtYaml.Canonical()
+		require.NoError(t, err)
+		assert.Equal(t, before, after)
+	})
+
+	t.Run("matches the expanded KRT", func(t *testing.T) {
+		templated := templatesKrt().Build()
+
+		normalized, err := templated.Normalize()
+		require.NoError(t, err)
+
+		expected, err := normalized.Canonical()
+		require.NoError(t, err)
+
+		actual, err := templated.Canonical()
+		require.NoError(t, err)
+		assert.Equal(t, expected, actual)
+	})
+
+	t.Run("golden bytes", func(t *testing.T) {
+		assert.Equal(t, `canonical: v1
+version: v1.0.0
+description: Test description
+workflows:
+    - name: test-workflow
+      type: training
+      processes:
+        - name: test-trigger
+          type: trigger
+          image: test-trigger-image
+          subscriptions:
+            - test-exit
+          resourceLimits:
+            CPU:
+                request: 100m
+                limit: 200m
+            memory:
+                request: 100M
+                limit: 200M
+        - name: test-exit
+          type: exit
+          image: test-exit-image
+          subscriptions:
+            - test-trigger
+          resourceLimits:
+            CPU:
+                request: 100m
+                limit: 200m
+            memory:
+                request: 100M
+                limit: 200M
+`, string(canonical))
+	})
+
+	t.Run("leaves out empty and default fields", func(t *testing.T) {
+		replicas := krt.DefaultNumberOfReplicas
+
+		explicit, err := NewKrtBuilder().
+			WithProcessReplicas(&replicas, 0).
+			WithProcessConfig(map[string]string{}, 0).
+			WithProcessNetworking(&krt.ProcessNetworking{TargetPort: 8080, DestinationPort: 9090, Protocol: krt.DefaultProtocol}, 0).
+			Build().Canonical()
+		require.NoError(t, err)
+
+		implicit, err := NewKrtBuilder().
+			WithProcessNetworking(&krt.ProcessNetworking{TargetPort: 8080, DestinationPort: 9090}, 0).
+			Build().Canonical()
+		require.NoError(t, err)
+
+		assert.Equal(t, string(implicit), string(explicit))
+		assert.NotContains(t, string(explicit), "protocol")
+	})
+
+	t.Run("keeps config entries with empty values", func(t *testing.T) {
+		withEmptyValue, err := NewKrtBuilder().WithProcessConfig(map[string]string{"KEY": ""}, 0).Build().Canonical()
+		require.NoError(t, err)
+		assert.Contains(t, string(withEmptyValue), `KEY: ""`)
+	})
+
+	t.Run("changes with the content", func(t *testing.T) {
+		changed, err := NewKrtBuilder().WithDescription("another description").Build().Canonical()
+		require.NoError(t, err)
+		assert.NotEqual(t, canonical, changed)
+	})
+}
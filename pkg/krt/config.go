@@ -0,0 +1,131 @@
+package krt
+
+import (
+	"sort"
+
+	"github.com/konstellation-io/krt/pkg/errors"
+)
+
+// ConfigSource is the level of the KRT where a config key was declared.
+type ConfigSource string
+
+const (
+	ConfigSourceProduct  ConfigSource = "product"
+	ConfigSourceWorkflow ConfigSource = "workflow"
+	ConfigSourceProcess  ConfigSource = "process"
+)
+
+// ConfigValue is the value a process sees for a config key, along with the level it was
+// taken from and the levels whose declaration of the same key it overrides.
+type ConfigValue struct {
+	Value    string
+	Source   ConfigSource
+	Shadowed []ConfigSource
+}
+
+// IsShadowing reports whether the value overrides a declaration from a lower precedence level.
+func (v ConfigValue) IsShadowing() bool {
+	return len(v.Shadowed) > 0
+}
+
+// EffectiveConfig is the final set of config keys a process sees.
+type EffectiveConfig map[string]ConfigValue
+
+// Keys returns the config keys sorted alphabetically.
+func (c EffectiveConfig) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// ShadowedKeys returns, sorted alphabetically, the keys declared at more than one level.
+func (c EffectiveConfig) ShadowedKeys() []string {
+	keys := make([]string, 0)
+
+	for _, key := range c.Keys() {
+		if c[key].IsShadowing() {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys
+}
+
+// Env returns the effective config as a plain key-value map.
+func (c EffectiveConfig) Env() map[string]string {
+	env := make(map[string]string, len(c))
+	for key, value := range c {
+		env[key] = value.Value
+	}
+
+	return env
+}
+
+// EffectiveConfig resolves the config a process sees in a given workflow.
+//
+// Keys are resolved with the following precedence, from highest to lowest:
+// process config, workflow config and product config. When a key is declared
+// at several levels, the value from the highest level is used and the lower
+// levels are reported as shadowed. The process config includes the config
+// inherited from the template it extends, see ExpandedProcess.
+func (krt *Krt) EffectiveConfig(workflowName, processName string) (EffectiveConfig, error) {
+	workflow, process, err := krt.findExpandedProcess(workflowName, processName)
+	if err != nil {
+		return nil, err
+	}
+
+	effectiveConfig := make(EffectiveConfig)
+
+	levels := []struct {
+		source ConfigSource
+		config map[string]string
+	}{
+		{ConfigSourceProduct, krt.Config},
+		{ConfigSourceWorkflow, workflow.Config},
+		{ConfigSourceProcess, process.Config},
+	}
+
+	for _, level := range levels {
+		for key, value := range level.config {
+			var shadowed []ConfigSource
+
+			if previous, ok := effectiveConfig[key]; ok {
+				shadowed = append(shadowed, previous.Shadowed...)
+				shadowed = append(shadowed, previous.Source)
+			}
+
+			effectiveConfig[key] = ConfigValue{
+				Value:    value,
+				Source:   level.source,
+				Shadowed: shadowed,
+			}
+		}
+	}
+
+	return effectiveConfig, nil
+}
+
+// findProcess returns the indexes of a process of a workflow, used to locate its validation errors.
+func (krt *Krt) findProcess(workflowName, processName string) (int, int, error) {
+	for workflowIdx := range krt.Workflows {
+		workflow := &krt.Workflows[workflowIdx]
+		if workflow.Name != workflowName {
+			continue
+		}
+
+		for processIdx := range workflow.Processes {
+			if workflow.Processes[processIdx].Name == processName {
+				return workflowIdx, processIdx, nil
+			}
+		}
+
+		return 0, 0, errors.ProcessNotFoundError(workflowName, processName)
+	}
+
+	return 0, 0, errors.WorkflowNotFoundError(workflowName)
+}
@@ -0,0 +1,53 @@
+//go:build unit
+
+package krt_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/konstellation-io/krt/pkg/errors"
+	"github.com/konstellation-io/krt/pkg/krt"
+)
+
+func TestKrt_EffectiveConfig(t *testing.T) {
+	krtYaml := NewKrtBuilder().
+		WithVersionConfig(map[string]string{"shared": "product", "product-only": "product"}).
+		WithWorkflowConfig(map[string]string{"shared": "workflow", "workflow-only": "workflow"}).
+		WithProcessConfig(map[string]string{"shared": "process", "process-only": "process"}, 0).
+		Build()
+
+	config, err := krtYaml.EffectiveConfig("test-workflow", "test-trigger")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"process-only", "product-only", "shared", "workflow-only"}, config.Keys())
+	assert.Equal(t, []string{"shared"}, config.ShadowedKeys())
+
+	assert.Equal(t, krt.ConfigValue{
+		Value:    "process",
+		Source:   krt.ConfigSourceProcess,
+		Shadowed: []krt.ConfigSource{krt.ConfigSourceProduct, krt.ConfigSourceWorkflow},
+	}, config["shared"])
+	assert.Equal(t, krt.ConfigSourceProduct, config["product-only"].Source)
+	assert.Equal(t, krt.ConfigSourceWorkflow, config["workflow-only"].Source)
+	assert.Equal(t, krt.ConfigSourceProcess, config["process-only"].Source)
+
+	assert.Equal(t, map[string]string{
+		"shared":        "process",
+		"product-only":  "product",
+		"workflow-only": "workflow",
+		"process-only":  "process",
+	}, config.Env())
+}
+
+func TestKrt_EffectiveConfig_NotFound(t *testing.T) {
+	krtYaml := NewKrtBuilder().Build()
+
+	_, err := krtYaml.EffectiveConfig("non-existent", "test-trigger")
+	assert.ErrorIs(t, err, errors.ErrWorkflowNotFound)
+
+	_, err = krtYaml.EffectiveConfig("test-workflow", "non-existent")
+	assert.ErrorIs(t, err, errors.ErrProcessNotFound)
+}
@@ -0,0 +1,23 @@
+package krt
+
+// EffectiveTimeout returns the timeout of a process of the workflow, falling back to the workflow default.
+// The process must be expanded, see Krt.ExpandedProcess, for the timeout of its template to be considered.
+func (workflow *Workflow) EffectiveTimeout(process *Process) string {
+	if process.Timeout != "" || workflow.Defaults == nil {
+		return process.Timeout
+	}
+
+	return workflow.Defaults.Timeout
+}
+
+// EffectiveRetry returns the retry policy of a process of the workflow, falling back to the workflow default.
+//
+// The process must be expanded, see Krt.ExpandedProcess, for the retry policy of its template to be considered.
+// Trigger processes are never retried, so the workflow default only applies to task and exit processes.
+func (workflow *Workflow) EffectiveRetry(process *Process) *RetryPolicy {
+	if process.Retry != nil || workflow.Defaults == nil || process.Type == ProcessTypeTrigger {
+		return process.Retry
+	}
+
+	return workflow.Defaults.Retry
+}
@@ -0,0 +1,36 @@
+//go:build unit
+
+package krt_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/konstellation-io/krt/pkg/krt"
+)
+
+func TestWorkflow_EffectiveTimeout(t *testing.T) {
+	workflow := NewKrtBuilder().
+		WithWorkflowDefaults(&krt.WorkflowDefaults{Timeout: "1m"}).
+		WithProcessTimeout("30s", 0).
+		Build().Workflows[0]
+
+	assert.Equal(t, "30s", workflow.EffectiveTimeout(&workflow.Processes[0]))
+	assert.Equal(t, "1m", workflow.EffectiveTimeout(&workflow.Processes[1]))
+}
+
+func TestWorkflow_EffectiveRetry(t *testing.T) {
+	defaultAttempts, processAttempts := 3, 5
+	defaultRetry := &krt.RetryPolicy{MaxAttempts: &defaultAttempts}
+	processRetry := &krt.RetryPolicy{MaxAttempts: &processAttempts}
+
+	workflow := NewKrtBuilder().
+		WithWorkflowDefaults(&krt.WorkflowDefaults{Retry: defaultRetry}).
+		WithProcess(NewKrtBuilder().WithProcessRetry(processRetry, 1).Build().Workflows[0].Processes[1]).
+		Build().Workflows[0]
+
+	assert.Nil(t, workflow.EffectiveRetry(&workflow.Processes[0]), "trigger processes are never retried")
+	assert.Equal(t, defaultRetry, workflow.EffectiveRetry(&workflow.Processes[1]))
+	assert.Equal(t, processRetry, workflow.EffectiveRetry(&workflow.Processes[2]))
+}
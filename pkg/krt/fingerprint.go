@@ -0,0 +1,174 @@
+package krt
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// Fingerprint identifies the content of a KRT, and of each of its workflows and processes, by their
+// SHA-256 hash. Hashes are computed over the canonical serialization, see Krt.Canonical, so they do not
+// change with the formatting of the file. Hashes of different canonical versions are not comparable.
+type Fingerprint struct {
+	Canonical string                `yaml:"canonical"`
+	Hash      string                `yaml:"hash"`
+	Workflows []WorkflowFingerprint `yaml:"workflows"`
+}
+
+// WorkflowFingerprint is the hash of a workflow, which changes whenever the hash of one of its processes does.
+type WorkflowFingerprint struct {
+	Name      string               `yaml:"name"`
+	Hash      string               `yaml:"hash"`
+	Processes []ProcessFingerprint `yaml:"processes"`
+}
+
+// ProcessFingerprint is the hash of everything a process is deployed with: its settings, the config and labels
+// it inherits and the workflow defaults it falls back to. A process only needs to be redeployed if it changes.
+type ProcessFingerprint struct {
+	Name string `yaml:"name"`
+	Hash string `yaml:"hash"`
+}
+
+// deployedProcess is the process as deployed, with everything it inherits resolved.
+type deployedProcess struct {
+	Process Process           `yaml:"process"`
+	Env     map[string]string `yaml:"env"`
+	Labels  map[string]string `yaml:"labels"`
+}
+
+// Fingerprint returns the hashes of the KRT and of each of its workflows and processes.
+func (krt *Krt) Fingerprint() (*Fingerprint, error) {
+	canonical, err := krt.canonical()
+	if err != nil {
+		return nil, err
+	}
+
+	canonicalYaml, err := marshalCanonical(canonical)
+	if err != nil {
+		return nil, err
+	}
+
+	fingerprint := &Fingerprint{
+		Canonical: CanonicalVersion,
+		Hash:      hash(canonicalYaml),
+		Workflows: make([]WorkflowFingerprint, 0, len(canonical.Workflows)),
+	}
+
+	for workflowIdx := range canonical.Workflows {
+		workflowFingerprint, err := canonical.workflowFingerprint(&canonical.Workflows[workflowIdx])
+		if err != nil {
+			return nil, err
+		}
+
+		fingerprint.Workflows = append(fingerprint.Workflows, *workflowFingerprint)
+	}
+
+	return fingerprint, nil
+}
+
+func (krt *Krt) workflowFingerprint(workflow *Workflow) (*WorkflowFingerprint, error) {
+	workflowFingerprint := &WorkflowFingerprint{
+		Name:      workflow.Name,
+		Processes: make([]ProcessFingerprint, 0, len(workflow.Processes)),
+	}
+
+	for processIdx := range workflow.Processes {
+		process := workflow.Processes[processIdx]
+
+		config, err := krt.EffectiveConfig(workflow.Name, process.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		labels, err := krt.EffectiveLabels(workflow.Name, process.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		process.Timeout = workflow.EffectiveTimeout(&process)
+		process.Retry = workflow.EffectiveRetry(&process)
+
+		processYaml, err := marshalCanonical(deployedProcess{Process: process, Env: config.Env(), Labels: labels})
+		if err != nil {
+			return nil, err
+		}
+
+		workflowFingerprint.Processes = append(workflowFingerprint.Processes, ProcessFingerprint{
+			Name: process.Name,
+			Hash: hash(processYaml),
+		})
+	}
+
+	workflowYaml, err := marshalCanonical(struct {
+		Workflow  *Workflow            `yaml:"workflow"`
+		Processes []ProcessFingerprint `yaml:"processes"`
+	}{workflow, workflowFingerprint.Processes})
+	if err != nil {
+		return nil, err
+	}
+
+	workflowFingerprint.Hash = hash(workflowYaml)
+
+	return workflowFingerprint, nil
+}
+
+// ProcessChanges are the processes that differ between two fingerprints, by workflow name, sorted as in the KRT.
+// The processes of a workflow added or removed are all added or removed.
+type ProcessChanges struct {
+	Added   map[string][]string
+	Changed map[string][]string
+	Removed map[string][]string
+}
+
+// ChangedProcesses returns the processes added, changed or removed since the previous fingerprint.
+func (f *Fingerprint) ChangedProcesses(previous *Fingerprint) *ProcessChanges {
+	changes := &ProcessChanges{
+		Added:   make(map[string][]string),
+		Changed: make(map[string][]string),
+		Removed: make(map[string][]string),
+	}
+
+	previousHashes := processHashes(previous)
+	currentHashes := processHashes(f)
+
+	for _, workflow := range f.Workflows {
+		for _, process := range workflow.Processes {
+			previousHash, ok := previousHashes[workflow.Name][process.Name]
+
+			switch {
+			case !ok:
+				changes.Added[workflow.Name] = append(changes.Added[workflow.Name], process.Name)
+			case previousHash != process.Hash:
+				changes.Changed[workflow.Name] = append(changes.Changed[workflow.Name], process.Name)
+			}
+		}
+	}
+
+	for _, workflow := range previous.Workflows {
+		for _, process := range workflow.Processes {
+			if _, ok := currentHashes[workflow.Name][process.Name]; !ok {
+				changes.Removed[workflow.Name] = append(changes.Removed[workflow.Name], process.Name)
+			}
+		}
+	}
+
+	return changes
+}
+
+// processHashes returns the hash of each process of the fingerprint, by workflow and process name.
+func processHashes(fingerprint *Fingerprint) map[string]map[string]string {
+	hashes := make(map[string]map[string]string, len(fingerprint.Workflows))
+
+	for _, workflow := range fingerprint.Workflows {
+		hashes[workflow.Name] = make(map[string]string, len(workflow.Processes))
+		for _, process := range workflow.Processes {
+			hashes[workflow.Name][process.Name] = process.Hash
+		}
+	}
+
+	return hashes
+}
+
+func hash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,127 @@
+//go:build unit
+
+package krt_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/konstellation-io/krt/pkg/krt"
+)
+
+func fingerprint(t *testing.T, krtYaml *krt.Krt) *krt.Fingerprint {
+	t.Helper()
+
+	fingerprint, err := krtYaml.Fingerprint()
+	require.NoError(t, err)
+
+	return fingerprint
+}
+
+func TestKrt_Fingerprint(t *testing.T) {
+	krtYaml := NewKrtBuilder().Build()
+	base := fingerprint(t, krtYaml)
+	maxAttempts := 3
+
+	canonical, err := krtYaml.Canonical()
+	require.NoError(t, err)
+
+	sum := sha256.Sum256(canonical)
+	assert.Equal(t, hex.EncodeToString(sum[:]), base.Hash)
+
+	require.Len(t, base.Workflows, 1)
+	assert.Equal(t, "test-workflow", base.Workflows[0].Name)
+	require.Len(t, base.Workflows[0].Processes, 2)
+	assert.Equal(t, "test-trigger", base.Workflows[0].Processes[0].Name)
+	assert.Equal(t, "test-exit", base.Workflows[0].Processes[1].Name)
+	assert.Equal(t, base, fingerprint(t, NewKrtBuilder().Build()))
+
+	tests := []struct {
+		name             string
+		krtYaml          *krt.Krt
+		changedProcesses map[string][]string
+		workflowChanged  bool
+	}{
+		{
+			name:             "product description",
+			krtYaml:          NewKrtBuilder().WithDescription("another description").Build(),
+			changedProcesses: map[string][]string{},
+		},
+		{
+			name:             "process image",
+			krtYaml:          NewKrtBuilder().WithProcessImage("another-image", 1).Build(),
+			changedProcesses: map[string][]string{"test-workflow": {"test-exit"}},
+			workflowChanged:  true,
+		},
+		{
+			name:             "inherited product config",
+			krtYaml:          NewKrtBuilder().WithVersionConfig(map[string]string{"REGION": "eu"}).Build(),
+			changedProcesses: map[string][]string{"test-workflow": {"test-trigger", "test-exit"}},
+			workflowChanged:  true,
+		},
+		{
+			name: "workflow default retry, not applied to triggers",
+			krtYaml: NewKrtBuilder().
+				WithWorkflowDefaults(&krt.WorkflowDefaults{Retry: &krt.RetryPolicy{MaxAttempts: &maxAttempts}}).
+				Build(),
+			changedProcesses: map[string][]string{"test-workflow": {"test-exit"}},
+			workflowChanged:  true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			changed := fingerprint(t, tc.krtYaml)
+
+			assert.NotEqual(t, base.Hash, changed.Hash)
+			assert.Equal(t, tc.workflowChanged, base.Workflows[0].Hash != changed.Workflows[0].Hash)
+			assert.Equal(t, &krt.ProcessChanges{
+				Added:   map[string][]string{},
+				Changed: tc.changedProcesses,
+				Removed: map[string][]string{},
+			}, changed.ChangedProcesses(base))
+		})
+	}
+}
+
+func TestFingerprint_ChangedProcesses(t *testing.T) {
+	base := fingerprint(t, NewKrtBuilder().Build())
+
+	withNewProcess := fingerprint(t, NewKrtBuilder().
+		WithProcess(krt.Process{Name: "test-task", Type: krt.ProcessTypeTask, Image: "test-task-image"}).
+		WithProcessImage("another-image", 1).
+		Build())
+
+	assert.Equal(t, &krt.ProcessChanges{
+		Added:   map[string][]string{"test-workflow": {"test-task"}},
+		Changed: map[string][]string{"test-workflow": {"test-exit"}},
+		Removed: map[string][]string{},
+	}, withNewProcess.ChangedProcesses(base))
+
+	assert.Equal(t, &krt.ProcessChanges{
+		Added:   map[string][]string{},
+		Changed: map[string][]string{"test-workflow": {"test-exit"}},
+		Removed: map[string][]string{"test-workflow": {"test-task"}},
+	}, base.ChangedProcesses(withNewProcess))
+
+	renamedWorkflow := fingerprint(t, NewKrtBuilder().WithWorkflowName("another-workflow").Build())
+
+	assert.Equal(t, &krt.ProcessChanges{
+		Added:   map[string][]string{"another-workflow": {"test-trigger", "test-exit"}},
+		Changed: map[string][]string{},
+		Removed: map[string][]string{"test-workflow": {"test-trigger", "test-exit"}},
+	}, renamedWorkflow.ChangedProcesses(base))
+}
+
+func TestKrt_Fingerprint_Golden(t *testing.T) {
+	base := fingerprint(t, NewKrtBuilder().Build())
+
+	assert.Equal(t, krt.CanonicalVersion, base.Canonical)
+	assert.Equal(t, "b4f3eb15ac641b8fd045ecac42f3a4172ae4818cdb8a8fa4de199cc850fccc56", base.Hash,
+		"hashes of the same content do not change between releases")
+	assert.Equal(t, "9efba6a312588a271f8234cbe243ef90d1d9fc9e2f48e3a1c6b43ad4721e3bb2", base.Workflows[0].Processes[0].Hash)
+}
@@ -1,17 +1,48 @@
 package krt
 
+import (
+	"sort"
+	"sync"
+)
+
+// API versions of the KRT format. Documents without apiVersion are from before the field
+// was introduced and are considered APIVersionV1Alpha1.
+const (
+	APIVersionV1Alpha1 = "krt/v1alpha1"
+	APIVersionV1       = "krt/v1"
+
+	CurrentAPIVersion = APIVersionV1
+)
+
 type Krt struct {
-	Version     string            `yaml:"version"`
-	Description string            `yaml:"description"`
-	Config      map[string]string `yaml:"config"`
-	Workflows   []Workflow        `yaml:"workflows"`
+	APIVersion       string                           `yaml:"apiVersion,omitempty"`
+	Version          string                           `yaml:"version"`
+	Description      string                           `yaml:"description"`
+	Config           map[string]string                `yaml:"config"`
+	Labels           map[string]string                `yaml:"labels,omitempty"`
+	Annotations      map[string]string                `yaml:"annotations,omitempty"`
+	ResourceProfiles map[string]ProcessResourceLimits `yaml:"resourceProfiles,omitempty"`
+	ProcessTemplates map[string]Process               `yaml:"processTemplates,omitempty"`
+	Workflows        []Workflow                       `yaml:"workflows"`
 }
 
 type Workflow struct {
-	Name      string            `yaml:"name"`
-	Type      WorkflowType      `yaml:"type"`
-	Config    map[string]string `yaml:"config"`
-	Processes []Process         `yaml:"processes"`
+	// File is the file the workflow was included from, empty for the workflows of the root file.
+	File        string            `yaml:"-"`
+	Name        string            `yaml:"name"`
+	Type        WorkflowType      `yaml:"type"`
+	Config      map[string]string `yaml:"config"`
+	Labels      map[string]string `yaml:"labels,omitempty"`
+	Annotations map[string]string `yaml:"annotations,omitempty"`
+	Defaults    *WorkflowDefaults `yaml:"defaults,omitempty"`
+	Processes   []Process         `yaml:"processes"`
+}
+
+// WorkflowDefaults are the settings applied to the processes of a workflow that do not declare their own.
+type WorkflowDefaults struct {
+	Timeout         string       `yaml:"timeout,omitempty"`
+	Retry           *RetryPolicy `yaml:"retry,omitempty"`
+	ResourceProfile string       `yaml:"resourceProfile,omitempty"`
 }
 
 type WorkflowType string
@@ -42,18 +73,35 @@ const (
 )
 
 type Process struct {
-	Name           string                 `yaml:"name"`
-	Type           ProcessType            `yaml:"type"`
-	Image          string                 `yaml:"image"`
-	Replicas       *int                   `yaml:"replicas" default:"1"`
-	GPU            *bool                  `yaml:"gpu" default:"false" `
-	Config         map[string]string      `yaml:"config"`
-	ObjectStore    *ProcessObjectStore    `yaml:"objectStore"`
-	Secrets        []string               `yaml:"secrets"`
-	Subscriptions  []string               `yaml:"subscriptions"`
-	Networking     *ProcessNetworking     `yaml:"networking"`
-	ResourceLimits *ProcessResourceLimits `yaml:"resourceLimits"`
-	NodeSelectors  map[string]string      `yaml:"nodeSelectors,omitempty"`
+	Name                 string                          `yaml:"name"`
+	Extends              string                          `yaml:"extends,omitempty"`
+	Type                 ProcessType                     `yaml:"type"`
+	Image                string                          `yaml:"image"`
+	Mode                 ProcessMode                     `yaml:"mode,omitempty"`
+	Job                  *ProcessJob                     `yaml:"job,omitempty"`
+	Replicas             *int                            `yaml:"replicas" default:"1"`
+	GPU                  *bool                           `yaml:"gpu" default:"false" `
+	Config               map[string]string               `yaml:"config"`
+	ObjectStore          *ProcessObjectStore             `yaml:"objectStore"`
+	Secrets              []string                        `yaml:"secrets"`
+	Subscriptions        []string                        `yaml:"subscriptions"`
+	SubscriptionSettings map[string]SubscriptionSettings `yaml:"subscriptionSettings,omitempty"`
+	Subtopics            []string                        `yaml:"subtopics,omitempty"`
+	Join                 *ProcessJoin                    `yaml:"join,omitempty"`
+	Timeout              string                          `yaml:"timeout,omitempty"`
+	Retry                *RetryPolicy                    `yaml:"retry,omitempty"`
+	Networking           *ProcessNetworking              `yaml:"networking"`
+	ResourceProfile      string                          `yaml:"resourceProfile,omitempty"`
+	ResourceLimits       *ProcessResourceLimits          `yaml:"resourceLimits"`
+	NodeSelectors        map[string]string               `yaml:"nodeSelectors,omitempty"`
+	Labels               map[string]string               `yaml:"labels,omitempty"`
+	Annotations          map[string]string               `yaml:"annotations,omitempty"`
+	Ingress              *ProcessIngress                 `yaml:"ingress,omitempty"`
+	Trigger              *ProcessTrigger                 `yaml:"trigger,omitempty"`
+
+	// unexpanded is set on the processes whose template or resource profile could not be applied, which are
+	// not validated as their fields are incomplete.
+	unexpanded bool
 }
 
 type ProcessType string
@@ -76,6 +124,154 @@ func (pt ProcessType) IsValid() bool {
 	return ok
 }
 
+const (
+	DefaultCronTimezone = "UTC"
+)
+
+// ProcessTrigger describes what starts a trigger process, holding the configuration specific to its kind.
+type ProcessTrigger struct {
+	Kind         TriggerKind          `yaml:"kind"`
+	Cron         *CronTrigger         `yaml:"cron,omitempty"`
+	ObjectStore  *ObjectStoreTrigger  `yaml:"objectStore,omitempty"`
+	MessageQueue *MessageQueueTrigger `yaml:"messageQueue,omitempty"`
+}
+
+type TriggerKind string
+
+const (
+	TriggerKindGRPC         TriggerKind = "grpc"
+	TriggerKindHTTP         TriggerKind = "http"
+	TriggerKindCron         TriggerKind = "cron"
+	TriggerKindObjectStore  TriggerKind = "object-store"
+	TriggerKindMessageQueue TriggerKind = "message-queue"
+)
+
+func (tk TriggerKind) IsValid() bool {
+	var triggerKindMap = map[string]TriggerKind{
+		string(TriggerKindGRPC):         TriggerKindGRPC,
+		string(TriggerKindHTTP):         TriggerKindHTTP,
+		string(TriggerKindCron):         TriggerKindCron,
+		string(TriggerKindObjectStore):  TriggerKindObjectStore,
+		string(TriggerKindMessageQueue): TriggerKindMessageQueue,
+	}
+
+	_, ok := triggerKindMap[string(tk)]
+
+	return ok
+}
+
+// CronTrigger runs a process on a cron schedule. The timezone is validated against the IANA database of the
+// host, binaries that must validate the same on every host should import "time/tzdata" to embed it.
+type CronTrigger struct {
+	Expression string `yaml:"expression"`
+	Timezone   string `yaml:"timezone" default:"UTC"`
+}
+
+type ObjectStoreTrigger struct {
+	Bucket string             `yaml:"bucket"`
+	Events []ObjectStoreEvent `yaml:"events"`
+	Prefix string             `yaml:"prefix,omitempty"`
+	Suffix string             `yaml:"suffix,omitempty"`
+}
+
+type ObjectStoreEvent string
+
+const (
+	ObjectStoreEventCreated ObjectStoreEvent = "created"
+	ObjectStoreEventDeleted ObjectStoreEvent = "deleted"
+)
+
+func (e ObjectStoreEvent) IsValid() bool {
+	var objectStoreEventMap = map[string]ObjectStoreEvent{
+		string(ObjectStoreEventCreated): ObjectStoreEventCreated,
+		string(ObjectStoreEventDeleted): ObjectStoreEventDeleted,
+	}
+
+	_, ok := objectStoreEventMap[string(e)]
+
+	return ok
+}
+
+type MessageQueueTrigger struct {
+	Subject string `yaml:"subject"`
+	Queue   string `yaml:"queue,omitempty"`
+}
+
+// ProcessMode sets how a process runs. Services are long-running and replicated, jobs run to completion
+// every time a message is received. Processes without mode are services.
+type ProcessMode string
+
+const (
+	ProcessModeService ProcessMode = "service"
+	ProcessModeJob     ProcessMode = "job"
+)
+
+func (pm ProcessMode) IsValid() bool {
+	var processModeMap = map[string]ProcessMode{
+		"":                         ProcessModeService,
+		string(ProcessModeService): ProcessModeService,
+		string(ProcessModeJob):     ProcessModeJob,
+	}
+
+	_, ok := processModeMap[string(pm)]
+
+	return ok
+}
+
+// ProcessJob are the settings of a process running as a job.
+type ProcessJob struct {
+	Completions  *int `yaml:"completions" default:"1"`
+	Parallelism  *int `yaml:"parallelism" default:"1"`
+	BackoffLimit *int `yaml:"backoffLimit" default:"6"`
+}
+
+// IsJob returns whether the process runs to completion instead of as a long-running service.
+func (process *Process) IsJob() bool {
+	return process.Mode == ProcessModeJob
+}
+
+// ProcessJoin sets how a process with several subscriptions is fed.
+//
+// With "any-of" the process receives every message from any of its subscriptions. With "all-of"
+// the process waits for a message from every subscription sharing the same correlation key value,
+// discarding the partial set when the timeout expires.
+type ProcessJoin struct {
+	Mode           JoinMode `yaml:"mode" default:"any-of"`
+	CorrelationKey string   `yaml:"correlationKey,omitempty"`
+	Timeout        string   `yaml:"timeout,omitempty"`
+}
+
+type JoinMode string
+
+const (
+	JoinModeAnyOf JoinMode = "any-of"
+	JoinModeAllOf JoinMode = "all-of"
+)
+
+func (jm JoinMode) IsValid() bool {
+	var joinModeMap = map[string]JoinMode{
+		string(JoinModeAnyOf): JoinModeAnyOf,
+		string(JoinModeAllOf): JoinModeAllOf,
+	}
+
+	_, ok := joinModeMap[string(jm)]
+
+	return ok
+}
+
+// RetryPolicy sets how many times a failed message is processed again and how long to wait between attempts.
+type RetryPolicy struct {
+	MaxAttempts *int          `yaml:"maxAttempts"`
+	Backoff     *RetryBackoff `yaml:"backoff,omitempty"`
+}
+
+// RetryBackoff is an exponential backoff, the wait is multiplied on every attempt up to the max wait.
+type RetryBackoff struct {
+	Initial    string   `yaml:"initial" default:"1s"`
+	Max        string   `yaml:"max" default:"1m"`
+	Multiplier *float64 `yaml:"multiplier" default:"2"`
+}
+
 type ProcessObjectStore struct {
 	Name  string           `yaml:"name"`
 	Scope ObjectStoreScope `yaml:"scope"`
@@ -101,28 +297,139 @@ func (s ObjectStoreScope) IsValid() bool {
 
 const (
 	DefaultProtocol = NetworkingProtocolHTTP
+	DefaultPortName = "default"
 )
 
+// ProcessNetworking declares the ports a process exposes.
+//
+// Ports can be declared either as a list of named ports or, for a single port,
+// with the targetPort, destinationPort and protocol fields.
 type ProcessNetworking struct {
+	TargetPort      int                `yaml:"targetPort,omitempty"`
+	DestinationPort int                `yaml:"destinationPort,omitempty"`
+	Protocol        NetworkingProtocol `yaml:"protocol,omitempty"`
+	Ports           []NetworkingPort   `yaml:"ports,omitempty"`
+}
+
+// SetDefaults sets the protocol of the single port form, which is left empty when ports are declared
+// as a list so setting both can be reported.
+func (n *ProcessNetworking) SetDefaults() {
+	if len(n.Ports) == 0 && n.Protocol == "" {
+		n.Protocol = DefaultProtocol
+	}
+}
+
+// GetPorts returns the ports declared, the single port form being returned as a port named "default".
+func (n *ProcessNetworking) GetPorts() []NetworkingPort {
+	if len(n.Ports) > 0 {
+		return n.Ports
+	}
+
+	return []NetworkingPort{
+		{
+			Name:            DefaultPortName,
+			TargetPort:      n.TargetPort,
+			DestinationPort: n.DestinationPort,
+			Protocol:        n.Protocol,
+		},
+	}
+}
+
+type NetworkingPort struct {
+	Name            string             `yaml:"name"`
 	TargetPort      int                `yaml:"targetPort"`
 	DestinationPort int                `yaml:"destinationPort"`
-	Protocol        NetworkingProtocol `yaml:"protocol" default:"HTTP" `
+	Protocol        NetworkingProtocol `yaml:"protocol" default:"HTTP"`
 }
 
 type NetworkingProtocol string
 
 const (
-	NetworkingProtocolHTTP NetworkingProtocol = "HTTP"
-	NetworkingProtocolGRPC NetworkingProtocol = "GRPC"
+	NetworkingProtocolHTTP      NetworkingProtocol = "HTTP"
+	NetworkingProtocolGRPC      NetworkingProtocol = "GRPC"
+	NetworkingProtocolTCP       NetworkingProtocol = "TCP"
+	NetworkingProtocolWebSocket NetworkingProtocol = "WEBSOCKET"
 )
 
+//nolint:gochecknoglobals // protocols can be registered by library users
+var networkingProtocolRegistry = struct {
+	sync.RWMutex
+	protocols map[NetworkingProtocol]bool
+}{
+	protocols: map[NetworkingProtocol]bool{
+		NetworkingProtocolHTTP:      true,
+		NetworkingProtocolGRPC:      true,
+		NetworkingProtocolTCP:       true,
+		NetworkingProtocolWebSocket: true,
+	},
+}
+
+// RegisterNetworkingProtocol makes the given protocols valid for process networking.
+func RegisterNetworkingProtocol(protocols ...NetworkingProtocol) {
+	networkingProtocolRegistry.Lock()
+	defer networkingProtocolRegistry.Unlock()
+
+	for _, protocol := range protocols {
+		networkingProtocolRegistry.protocols[protocol] = true
+	}
+}
+
+// UnregisterNetworkingProtocol makes the given protocols no longer valid for process networking.
+func UnregisterNetworkingProtocol(protocols ...NetworkingProtocol) {
+	networkingProtocolRegistry.Lock()
+	defer networkingProtocolRegistry.Unlock()
+
+	for _, protocol := range protocols {
+		delete(networkingProtocolRegistry.protocols, protocol)
+	}
+}
+
+// NetworkingProtocols returns the registered protocols sorted alphabetically.
+func NetworkingProtocols() []NetworkingProtocol {
+	networkingProtocolRegistry.RLock()
+	defer networkingProtocolRegistry.RUnlock()
+
+	protocols := make([]NetworkingProtocol, 0, len(networkingProtocolRegistry.protocols))
+	for protocol := range networkingProtocolRegistry.protocols {
+		protocols = append(protocols, protocol)
+	}
+
+	sort.Slice(protocols, func(i, j int) bool { return protocols[i] < protocols[j] })
+
+	return protocols
+}
+
 func (np NetworkingProtocol) IsValid() bool {
-	var networkingProtocolMap = map[string]NetworkingProtocol{
-		string(NetworkingProtocolHTTP): NetworkingProtocolHTTP,
-		string(NetworkingProtocolGRPC): NetworkingProtocolGRPC,
+	networkingProtocolRegistry.RLock()
+	defer networkingProtocolRegistry.RUnlock()
+
+	return networkingProtocolRegistry.protocols[np]
+}
+
+// ProcessIngress exposes a trigger process outside the cluster. Ingresses are internal and
+// routed from "/" unless set otherwise.
+type ProcessIngress struct {
+	Host       string            `yaml:"host"`
+	PathPrefix string            `yaml:"pathPrefix" default:"/"`
+	Port       string            `yaml:"port,omitempty"`
+	TLSSecret  string            `yaml:"tlsSecret,omitempty"`
+	Visibility IngressVisibility `yaml:"visibility" default:"internal"`
+}
+
+type IngressVisibility string
+
+const (
+	IngressVisibilityPublic   IngressVisibility = "public"
+	IngressVisibilityInternal IngressVisibility = "internal"
+)
+
+func (v IngressVisibility) IsValid() bool {
+	var ingressVisibilityMap = map[string]IngressVisibility{
+		string(IngressVisibilityPublic):   IngressVisibilityPublic,
+		string(IngressVisibilityInternal): IngressVisibilityInternal,
 	}
 
-	_, ok := networkingProtocolMap[string(np)]
+	_, ok := ingressVisibilityMap[string(v)]
 
 	return ok
 }
@@ -132,6 +439,8 @@ type ResourceLimit struct {
 	Limit   string `yaml:"limit"`
 }
 
+// ProcessResourceLimits are the resources of a process. Named sets of them can be declared as resource
+// profiles, to be used by processes instead of declaring their own.
 type ProcessResourceLimits struct {
 	CPU    *ResourceLimit `yaml:"CPU"`
 	Memory *ResourceLimit `yaml:"memory"`
@@ -61,6 +61,11 @@ func NewKrtBuilder() *KrtBuilder {
 	}
 }
 
+func (k *KrtBuilder) WithAPIVersion(apiVersion string) *KrtBuilder {
+	k.krtYaml.APIVersion = apiVersion
+	return k
+}
+
 func (k *KrtBuilder) WithVersion(version string) *KrtBuilder {
 	k.krtYaml.Version = version
 	return k
@@ -76,11 +81,36 @@ func (k *KrtBuilder) WithVersionConfig(config map[string]string) *KrtBuilder {
 	return k
 }
 
+func (k *KrtBuilder) WithLabels(labels map[string]string) *KrtBuilder {
+	k.krtYaml.Labels = labels
+	return k
+}
+
+func (k *KrtBuilder) WithAnnotations(annotations map[string]string) *KrtBuilder {
+	k.krtYaml.Annotations = annotations
+	return k
+}
+
+func (k *KrtBuilder) WithResourceProfiles(profiles map[string]krt.ProcessResourceLimits) *KrtBuilder {
+	k.krtYaml.ResourceProfiles = profiles
+	return k
+}
+
+func (k *KrtBuilder) WithProcessTemplates(templates map[string]krt.Process) *KrtBuilder {
+	k.krtYaml.ProcessTemplates = templates
+	return k
+}
+
 func (k *KrtBuilder) WithWorkflows(workflows []krt.Workflow) *KrtBuilder {
 	k.krtYaml.Workflows = workflows
 	return k
 }
 
+func (k *KrtBuilder) WithWorkflow(workflow krt.Workflow) *KrtBuilder {
+	k.krtYaml.Workflows = append(k.krtYaml.Workflows, workflow)
+	return k
+}
+
 func (k *KrtBuilder) WithWorkflowName(name string) *KrtBuilder {
 	k.krtYaml.Workflows[0].Name = name
 	return k
@@ -91,11 +121,31 @@ func (k *KrtBuilder) WithWorkflowType(workflowType krt.WorkflowType) *KrtBuilder
 	return k
 }
 
+func (k *KrtBuilder) WithWorkflowConfig(config map[string]string) *KrtBuilder {
+	k.krtYaml.Workflows[0].Config = config
+	return k
+}
+
+func (k *KrtBuilder) WithWorkflowLabels(labels map[string]string) *KrtBuilder {
+	k.krtYaml.Workflows[0].Labels = labels
+	return k
+}
+
+func (k *KrtBuilder) WithWorkflowDefaults(defaults *krt.WorkflowDefaults) *KrtBuilder {
+	k.krtYaml.Workflows[0].Defaults = defaults
+	return k
+}
+
 func (k *KrtBuilder) WithProcesses(processes []krt.Process) *KrtBuilder {
 	k.krtYaml.Workflows[0].Processes = processes
 	return k
 }
 
+func (k *KrtBuilder) WithProcess(process krt.Process) *KrtBuilder {
+	k.krtYaml.Workflows[0].Processes = append(k.krtYaml.Workflows[0].Processes, process)
+	return k
+}
+
 func (k *KrtBuilder) WithProcessName(name string, processIdx int) *KrtBuilder {
 	k.krtYaml.Workflows[0].Processes[processIdx].Name = name
 	return k
@@ -116,6 +166,13 @@ func (k *KrtBuilder) WithProcessReplicas(replicas *int, processIdx int) *KrtBuil
 	return k
 }
 
+func (k *KrtBuilder) WithProcessMode(mode krt.ProcessMode, job *krt.ProcessJob, processIdx int) *KrtBuilder {
+	k.krtYaml.Workflows[0].Processes[processIdx].Mode = mode
+	k.krtYaml.Workflows[0].Processes[processIdx].Job = job
+
+	return k
+}
+
 func (k *KrtBuilder) WithProcessGPU(gpu *bool, processIdx int) *KrtBuilder {
 	k.krtYaml.Workflows[0].Processes[processIdx].GPU = gpu
 	return k
@@ -141,11 +198,45 @@ func (k *KrtBuilder) WithProcessSubscriptions(subscriptions []string, processIdx
 	return k
 }
 
+func (k *KrtBuilder) WithProcessSubscriptionSettings(
+	subscription string,
+	settings krt.SubscriptionSettings,
+	processIdx int,
+) *KrtBuilder {
+	k.krtYaml.Workflows[0].Processes[processIdx].SubscriptionSettings = map[string]krt.SubscriptionSettings{subscription: settings}
+	return k
+}
+
+func (k *KrtBuilder) WithProcessSubtopics(subtopics []string, processIdx int) *KrtBuilder {
+	k.krtYaml.Workflows[0].Processes[processIdx].Subtopics = subtopics
+	return k
+}
+
+func (k *KrtBuilder) WithProcessJoin(join *krt.ProcessJoin, processIdx int) *KrtBuilder {
+	k.krtYaml.Workflows[0].Processes[processIdx].Join = join
+	return k
+}
+
+func (k *KrtBuilder) WithProcessTimeout(timeout string, processIdx int) *KrtBuilder {
+	k.krtYaml.Workflows[0].Processes[processIdx].Timeout = timeout
+	return k
+}
+
+func (k *KrtBuilder) WithProcessRetry(retry *krt.RetryPolicy, processIdx int) *KrtBuilder {
+	k.krtYaml.Workflows[0].Processes[processIdx].Retry = retry
+	return k
+}
+
 func (k *KrtBuilder) WithProcessNetworking(networking *krt.ProcessNetworking, processIdx int) *KrtBuilder {
 	k.krtYaml.Workflows[0].Processes[processIdx].Networking = networking
 	return k
 }
 
+func (k *KrtBuilder) WithProcessResourceProfile(resourceProfile string, processIdx int) *KrtBuilder {
+	k.krtYaml.Workflows[0].Processes[processIdx].ResourceProfile = resourceProfile
+	return k
+}
+
 func (k *KrtBuilder) WithProcessResourceLimits(resourceLimits *krt.ProcessResourceLimits, processIdx int) *KrtBuilder {
 	k.krtYaml.Workflows[0].Processes[processIdx].ResourceLimits = resourceLimits
 	return k
@@ -156,6 +247,21 @@ func (k *KrtBuilder) WithNodeSelectors(nodeSelectors map[string]string, processI
 	return k
 }
 
+func (k *KrtBuilder) WithProcessLabels(labels map[string]string, processIdx int) *KrtBuilder {
+	k.krtYaml.Workflows[0].Processes[processIdx].Labels = labels
+	return k
+}
+
+func (k *KrtBuilder) WithProcessAnnotations(annotations map[string]string, processIdx int) *KrtBuilder {
+	k.krtYaml.Workflows[0].Processes[processIdx].Annotations = annotations
+	return k
+}
+
+func (k *KrtBuilder) WithProcessIngress(ingress *krt.ProcessIngress, processIdx int) *KrtBuilder {
+	k.krtYaml.Workflows[0].Processes[processIdx].Ingress = ingress
+	return k
+}
+
 func (k *KrtBuilder) Build() *krt.Krt {
 	return k.krtYaml
 }
@@ -0,0 +1,21 @@
+package krt
+
+// EffectiveLabels returns the labels of a process merged with the labels inherited from its
+// workflow and the product. Process labels take precedence over workflow labels, and these
+// over product labels. Process labels include the labels inherited from the template it extends.
+func (krt *Krt) EffectiveLabels(workflowName, processName string) (map[string]string, error) {
+	workflow, process, err := krt.findExpandedProcess(workflowName, processName)
+	if err != nil {
+		return nil, err
+	}
+
+	effectiveLabels := make(map[string]string, len(krt.Labels)+len(workflow.Labels)+len(process.Labels))
+
+	for _, labels := range []map[string]string{krt.Labels, workflow.Labels, process.Labels} {
+		for key, value := range labels {
+			effectiveLabels[key] = value
+		}
+	}
+
+	return effectiveLabels, nil
+}
@@ -0,0 +1,33 @@
+//go:build unit
+
+package krt_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/konstellation-io/krt/pkg/errors"
+)
+
+func TestKrt_EffectiveLabels(t *testing.T) {
+	krtYaml := NewKrtBuilder().
+		WithLabels(map[string]string{"owner": "product-team", "cost-center": "ai"}).
+		WithWorkflowLabels(map[string]string{"owner": "training-team", "stage": "training"}).
+		WithProcessLabels(map[string]string{"stage": "entrypoint"}, 0).
+		Build()
+
+	labels, err := krtYaml.EffectiveLabels("test-workflow", "test-trigger")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"owner": "training-team", "cost-center": "ai", "stage": "entrypoint"}, labels)
+
+	labels, err = krtYaml.EffectiveLabels("test-workflow", "test-exit")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"owner": "training-team", "cost-center": "ai", "stage": "training"}, labels)
+}
+
+func TestKrt_EffectiveLabels_NotFound(t *testing.T) {
+	_, err := NewKrtBuilder().Build().EffectiveLabels("test-workflow", "non-existent")
+	assert.ErrorIs(t, err, errors.ErrProcessNotFound)
+}
@@ -41,6 +41,26 @@ func (pb *ProcessBuilder) WithNodeSelectors(nodeSelectors map[string]string) *Pr
 	return pb
 }
 
+func (pb *ProcessBuilder) WithNetworking(networking *krt.ProcessNetworking) *ProcessBuilder {
+	pb.process.Networking = networking
+	return pb
+}
+
+func (pb *ProcessBuilder) WithType(processType krt.ProcessType) *ProcessBuilder {
+	pb.process.Type = processType
+	return pb
+}
+
+func (pb *ProcessBuilder) WithIngress(ingress *krt.ProcessIngress) *ProcessBuilder {
+	pb.process.Ingress = ingress
+	return pb
+}
+
+func (pb *ProcessBuilder) WithTrigger(trigger *krt.ProcessTrigger) *ProcessBuilder {
+	pb.process.Trigger = trigger
+	return pb
+}
+
 func (pb *ProcessBuilder) Build() *krt.Process {
 	return pb.process
 }
@@ -0,0 +1,82 @@
+package krt
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/konstellation-io/krt/pkg/errors"
+)
+
+// EffectiveResourceProfile returns the resource profile used by a process of the workflow, falling back
+// to the workflow default.
+func (workflow *Workflow) EffectiveResourceProfile(process *Process) string {
+	if process.ResourceProfile != "" || workflow.Defaults == nil {
+		return process.ResourceProfile
+	}
+
+	return workflow.Defaults.ResourceProfile
+}
+
+// ValidateResourceProfiles checks the resource profile names. Profiles are not validated themselves,
+// only the resource limits of the processes using them.
+func (krt *Krt) ValidateResourceProfiles() error {
+	var totalError error
+
+	for _, name := range sortedResourceProfileNames(krt.ResourceProfiles) {
+		totalError = errors.Join(totalError, validateName(name, fmt.Sprintf("krt.resourceProfiles.%s", name)))
+	}
+
+	return totalError
+}
+
+// workflowResourceProfile returns the default resource profile of the workflow, if any.
+func (krt *Krt) workflowResourceProfile(workflow *Workflow, location string) (*ProcessResourceLimits, error) {
+	if workflow.Defaults == nil || workflow.Defaults.ResourceProfile == "" {
+		return nil, nil
+	}
+
+	return krt.resourceProfile(workflow.Defaults.ResourceProfile, location)
+}
+
+func (krt *Krt) resourceProfile(name, location string) (*ProcessResourceLimits, error) {
+	profile, ok := krt.ResourceProfiles[name]
+	if !ok {
+		return nil, errors.ResourceProfileNotFoundError(name, location)
+	}
+
+	return &profile, nil
+}
+
+// applyResourceProfile sets the resource limits the process leaves unset from the profile it uses,
+// or from the workflow default profile if it does not declare one.
+func (krt *Krt) applyResourceProfile(process *Process, workflowProfile *ProcessResourceLimits, location string) error {
+	profile := workflowProfile
+
+	if process.ResourceProfile != "" {
+		var err error
+
+		profile, err = krt.resourceProfile(process.ResourceProfile, location+".resourceProfile")
+		if err != nil {
+			return err
+		}
+	}
+
+	if profile != nil {
+		resourceLimits := reflect.ValueOf(&process.ResourceLimits).Elem()
+		mergeValues(resourceLimits, reflect.ValueOf(profile))
+	}
+
+	return nil
+}
+
+func sortedResourceProfileNames(profiles map[string]ProcessResourceLimits) []string {
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
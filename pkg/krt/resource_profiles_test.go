@@ -0,0 +1,157 @@
+//go:build unit
+
+package krt_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/konstellation-io/krt/pkg/errors"
+	"github.com/konstellation-io/krt/pkg/krt"
+)
+
+func resourceProfilesKrt() *KrtBuilder {
+	return NewKrtBuilder().
+		WithResourceProfiles(map[string]krt.ProcessResourceLimits{
+			"small": {
+				CPU:    &krt.ResourceLimit{Request: "250m", Limit: "500m"},
+				Memory: &krt.ResourceLimit{Request: "250M", Limit: "500M"},
+			},
+			"large": {
+				CPU:    &krt.ResourceLimit{Request: "1", Limit: "2"},
+				Memory: &krt.ResourceLimit{Request: "1Gi", Limit: "2Gi"},
+			},
+		}).
+		WithWorkflowDefaults(&krt.WorkflowDefaults{ResourceProfile: "small"}).
+		WithProcess(krt.Process{
+			Name:            "worker",
+			Type:            krt.ProcessTypeTask,
+			Image:           "worker-image",
+			ResourceProfile: "large",
+			ResourceLimits: &krt.ProcessResourceLimits{
+				Memory: &krt.ResourceLimit{Request: "4Gi", Limit: "4Gi"},
+			},
+			Subscriptions: []string{"test-trigger"},
+		}).
+		WithProcessSubscriptions([]string{"worker"}, 1)
+}
+
+func TestKrt_Normalize_ResourceProfiles(t *testing.T) {
+	krtYaml := resourceProfilesKrt().WithProcessResourceLimits(nil, 0).Build()
+
+	normalized, err := krtYaml.Normalize()
+	require.NoError(t, err)
+
+	assert.Nil(t, normalized.ResourceProfiles)
+	assert.Nil(t, normalized.Workflows[0].Defaults, "defaults left empty are removed")
+
+	trigger := normalized.Workflows[0].Processes[0]
+	assert.Equal(t, &krt.ProcessResourceLimits{
+		CPU:    &krt.ResourceLimit{Request: "250m", Limit: "500m"},
+		Memory: &krt.ResourceLimit{Request: "250M", Limit: "500M"},
+	}, trigger.ResourceLimits, "the workflow default profile is used")
+
+	exit := normalized.Workflows[0].Processes[1]
+	assert.Equal(t, "200m", exit.ResourceLimits.CPU.Limit, "limits declared in the process are kept")
+
+	worker := normalized.Workflows[0].Processes[2]
+	assert.Empty(t, worker.ResourceProfile)
+	assert.Equal(t, &krt.ProcessResourceLimits{
+		CPU:    &krt.ResourceLimit{Request: "1", Limit: "2"},
+		Memory: &krt.ResourceLimit{Request: "4Gi", Limit: "4Gi"},
+	}, worker.ResourceLimits, "the process profile takes precedence over the workflow default")
+
+	assert.Equal(t, "small", krtYaml.Workflows[0].Defaults.ResourceProfile, "the original KRT is not modified")
+	assert.Nil(t, krtYaml.Workflows[0].Processes[0].ResourceLimits)
+}
+
+func TestKrt_ExpandedProcess_ResourceProfile(t *testing.T) {
+	krtYaml := resourceProfilesKrt().Build()
+
+	worker, err := krtYaml.ExpandedProcess("test-workflow", "worker")
+	require.NoError(t, err)
+	assert.Equal(t, "2", worker.ResourceLimits.CPU.Limit)
+
+	krtYaml.Workflows[0].Defaults.ResourceProfile = "non-existent"
+
+	_, err = krtYaml.ExpandedProcess("test-workflow", "worker")
+	assert.ErrorIs(t, err, errors.ErrResourceProfileNotFound)
+	assert.ErrorContains(t, err, `"non-existent", in krt.workflows[0].defaults.resourceProfile`)
+
+	krtYaml.Workflows[0].Defaults.ResourceProfile = "small"
+	krtYaml.Workflows[0].Processes[2].ResourceProfile = "non-existent"
+
+	_, err = krtYaml.ExpandedProcess("test-workflow", "worker")
+	assert.ErrorIs(t, err, errors.ErrResourceProfileNotFound)
+	assert.ErrorContains(t, err, `"non-existent", in krt.workflows[0].processes[2].resourceProfile`)
+}
+
+func TestKrt_Validate_ResourceProfiles(t *testing.T) {
+	testCases := []struct {
+		name        string
+		krtYaml     *krt.Krt
+		errorType   error
+		errorString string
+		errorCount  int
+	}{
+		{
+			name:        "valid processes using resource profiles",
+			krtYaml:     resourceProfilesKrt().WithProcessResourceLimits(nil, 0).Build(),
+			errorType:   nil,
+			errorString: "",
+		},
+		{
+			name:        "process using a non existent resource profile",
+			krtYaml:     resourceProfilesKrt().WithProcessResourceProfile("non-existent", 2).Build(),
+			errorType:   errors.ErrResourceProfileNotFound,
+			errorString: `resource profile not found: "non-existent", in krt.workflows[0].processes[2].resourceProfile`,
+		},
+		{
+			name: "workflow default resource profile that does not exist",
+			krtYaml: resourceProfilesKrt().WithProcessResourceLimits(nil, 0).
+				WithWorkflowDefaults(&krt.WorkflowDefaults{ResourceProfile: "non-existent"}).Build(),
+			errorType:   errors.ErrResourceProfileNotFound,
+			errorString: `resource profile not found: "non-existent", in krt.workflows[0].defaults.resourceProfile`,
+			errorCount:  1,
+		},
+		{
+			name: "resource profile with an invalid name",
+			krtYaml: resourceProfilesKrt().WithResourceProfiles(map[string]krt.ProcessResourceLimits{
+				"Invalid": {},
+				"small":   {},
+				"large":   {},
+			}).Build(),
+			errorType:   errors.ErrInvalidFieldName,
+			errorString: errors.InvalidFieldNameError("krt.resourceProfiles.Invalid").Error(),
+		},
+		{
+			name: "process with an invalid limit from its resource profile",
+			krtYaml: resourceProfilesKrt().WithProcessResourceLimits(nil, 0).WithResourceProfiles(map[string]krt.ProcessResourceLimits{
+				"small": {CPU: &krt.ResourceLimit{Request: "invalid"}, Memory: &krt.ResourceLimit{Request: "100M"}},
+				"large": {CPU: &krt.ResourceLimit{Request: "1"}},
+			}).Build(),
+			errorType:   errors.ErrInvalidProcessCPUResourceLimit,
+			errorString: errors.InvalidProcessCPUError("krt.workflows[0].processes[0].resourceLimits.CPU.request").Error(),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.krtYaml.Validate()
+			if tc.errorType == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.ErrorIs(t, err, tc.errorType)
+			assert.ErrorContains(t, err, tc.errorString)
+
+			if tc.errorCount > 0 {
+				assert.Len(t, strings.Split(err.Error(), "\n"), tc.errorCount)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package krt
+
+import (
+	"sort"
+	"strings"
+)
+
+// SubscriptionSettings are the delivery settings of a subscription of a process. They are declared in the
+// subscriptionSettings of the process, by subscription, so subscriptions are still plain strings:
+//
+//	subscriptions:
+//	  - etl
+//	  - email-classificator.repairs
+//	subscriptionSettings:
+//	  email-classificator.repairs:
+//	    maxDeliver: 5
+//	    ackWait: 30s
+//	    maxAckPending: 100
+//	    deadLetter: repairs-fallback
+type SubscriptionSettings struct {
+	MaxDeliver    *int   `yaml:"maxDeliver,omitempty"`
+	AckWait       string `yaml:"ackWait,omitempty"`
+	MaxAckPending *int   `yaml:"maxAckPending,omitempty"`
+	DeadLetter    string `yaml:"deadLetter,omitempty"`
+}
+
+// subscriptionReference is a subscription split into the workflow and process it points to and,
+// for subscriptions such as "email-classificator.repairs", the subtopic.
+//
+// The workflow is only set for qualified subscriptions such as "serving/exitpoint.predictions".
+type subscriptionReference struct {
+	workflow string
+	process  string
+	subtopic string
+}
+
+func parseSubscription(subscription string) subscriptionReference {
+	workflow, processWithSubtopic, qualified := strings.Cut(subscription, "/")
+	if !qualified {
+		workflow, processWithSubtopic = "", subscription
+	}
+
+	process, subtopic, _ := strings.Cut(processWithSubtopic, ".")
+
+	return subscriptionReference{
+		workflow: workflow,
+		process:  process,
+		subtopic: subtopic,
+	}
+}
+
+// String returns the subscription in its qualified form, "workflow/process.subtopic".
+func (r subscriptionReference) String() string {
+	reference := r.process
+
+	if r.workflow != "" {
+		reference = r.workflow + "/" + reference
+	}
+
+	if r.subtopic != "" {
+		reference += "." + r.subtopic
+	}
+
+	return reference
+}
+
+// local returns the reference without its workflow when it is qualified with the given one, so
+// "serving/etl" is handled as "etl" inside the serving workflow.
+func (r subscriptionReference) local(workflowName string) subscriptionReference {
+	if r.workflow == workflowName {
+		r.workflow = ""
+	}
+
+	return r
+}
+
+// isCrossWorkflow reports whether the subscription points to a workflow other than the given one.
+func (r subscriptionReference) isCrossWorkflow(workflowName string) bool {
+	return r.workflow != "" && r.workflow != workflowName
+}
+
+// closestMatch returns the candidate with the lowest edit distance to the value,
+// or an empty string if there are no candidates.
+func closestMatch(value string, candidates []string) string {
+	closest, closestDistance := "", -1
+
+	for _, candidate := range candidates {
+		distance := levenshteinDistance(value, candidate)
+		if closestDistance == -1 || distance < closestDistance {
+			closest, closestDistance = candidate, distance
+		}
+	}
+
+	return closest
+}
+
+func levenshteinDistance(a, b string) int {
+	source, target := []rune(a), []rune(b)
+
+	previous := make([]int, len(target)+1)
+	current := make([]int, len(target)+1)
+
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(source); i++ {
+		current[0] = i
+
+		for j := 1; j <= len(target); j++ {
+			substitutionCost := 1
+			if source[i-1] == target[j-1] {
+				substitutionCost = 0
+			}
+
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+substitutionCost)
+		}
+
+		previous, current = current, previous
+	}
+
+	return previous[len(target)]
+}
+
+func sortedSubscriptionSettings(settings map[string]SubscriptionSettings) []string {
+	subscriptions := make([]string, 0, len(settings))
+	for subscription := range settings {
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	sort.Strings(subscriptions)
+
+	return subscriptions
+}
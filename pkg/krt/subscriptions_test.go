@@ -0,0 +1,48 @@
+//go:build unit
+
+package krt_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/konstellation-io/krt/pkg/krt"
+)
+
+func TestSubscriptionSettings_YAML(t *testing.T) {
+	processYaml := `subscriptions:
+  - etl
+  - email-classificator.repairs
+subscriptionSettings:
+  email-classificator.repairs:
+    maxDeliver: 5
+    ackWait: 30s
+    deadLetter: repairs-fallback
+`
+
+	var process krt.Process
+
+	err := yaml.Unmarshal([]byte(processYaml), &process)
+	require.NoError(t, err)
+
+	maxDeliver := 5
+	assert.Equal(t, []string{"etl", "email-classificator.repairs"}, process.Subscriptions)
+	assert.Equal(t, map[string]krt.SubscriptionSettings{
+		"email-classificator.repairs": {MaxDeliver: &maxDeliver, AckWait: "30s", DeadLetter: "repairs-fallback"},
+	}, process.SubscriptionSettings)
+
+	marshalledProcess, err := yaml.Marshal(krt.Process{
+		Subscriptions:        process.Subscriptions,
+		SubscriptionSettings: process.SubscriptionSettings,
+	})
+	require.NoError(t, err)
+	assert.Contains(t, string(marshalledProcess), `subscriptionSettings:
+    email-classificator.repairs:
+        maxDeliver: 5
+        ackWait: 30s
+        deadLetter: repairs-fallback
+`)
+}
@@ -0,0 +1,327 @@
+package krt
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/creasty/defaults"
+
+	"github.com/konstellation-io/krt/pkg/errors"
+)
+
+// Normalize returns a copy of the KRT with every process that extends a template or uses a resource
+// profile expanded.
+//
+// A process extending a template is deep merged with it, following these rules:
+//   - Fields set in the process take precedence over the fields of the template.
+//   - Maps, such as config or nodeSelectors, are merged key by key.
+//   - Nested settings, such as resourceLimits or networking, are merged field by field.
+//   - Lists, such as subscriptions or secrets, are inherited only if the process leaves them empty.
+//
+// Templates can extend other templates, the nearest template taking precedence. The resource limits
+// the process leaves unset are then taken from its resource profile, or from the default profile of
+// the workflow. Defaults are applied to expanded processes, and the copy has neither templates,
+// extends nor resource profiles.
+func (krt *Krt) Normalize() (*Krt, error) {
+	normalized := cloneValue(reflect.ValueOf(krt)).Interface().(*Krt) //nolint:forcetypeassert // clone of a *Krt
+
+	if err := krt.expandWorkflows(normalized.Workflows); err != nil {
+		return nil, err
+	}
+
+	for workflowIdx := range normalized.Workflows {
+		workflow := &normalized.Workflows[workflowIdx]
+
+		for processIdx := range workflow.Processes {
+			workflow.Processes[processIdx].Extends = ""
+			workflow.Processes[processIdx].ResourceProfile = ""
+		}
+
+		if workflow.Defaults != nil {
+			workflow.Defaults.ResourceProfile = ""
+
+			if *workflow.Defaults == (WorkflowDefaults{}) {
+				workflow.Defaults = nil
+			}
+		}
+	}
+
+	normalized.ProcessTemplates = nil
+	normalized.ResourceProfiles = nil
+
+	return normalized, nil
+}
+
+// ExpandedProcess returns a process as it is after extending its template and applying its resource
+// profile, if any.
+func (krt *Krt) ExpandedProcess(workflowName, processName string) (*Process, error) {
+	_, process, err := krt.findExpandedProcess(workflowName, processName)
+
+	return process, err
+}
+
+// findExpandedProcess returns a process of a workflow expanded as in ExpandedProcess, along with its workflow.
+func (krt *Krt) findExpandedProcess(workflowName, processName string) (*Workflow, *Process, error) {
+	workflowIdx, processIdx, err := krt.findProcess(workflowName, processName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	workflow := &krt.Workflows[workflowIdx]
+	process := &workflow.Processes[processIdx]
+
+	workflowProfile, err := krt.workflowResourceProfile(
+		workflow, fmt.Sprintf("krt.workflows[%d].defaults.resourceProfile", workflowIdx),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !needsExpansion(process, workflowProfile) {
+		return workflow, process, nil
+	}
+
+	expanded, err := krt.expandProcess(process, workflowProfile, fmt.Sprintf("krt.workflows[%d].processes[%d]", workflowIdx, processIdx))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return workflow, expanded, nil
+}
+
+// expandWorkflows replaces the processes of the given copy of the KRT workflows that extend a template
+// or use a resource profile by their expanded version.
+func (krt *Krt) expandWorkflows(workflows []Workflow) error {
+	var totalError error
+
+	for workflowIdx := range workflows {
+		workflowProfile, profileError := krt.workflowResourceProfile(
+			&krt.Workflows[workflowIdx], fmt.Sprintf("krt.workflows[%d].defaults.resourceProfile", workflowIdx),
+		)
+		totalError = errors.Join(totalError, profileError)
+
+		processes := workflows[workflowIdx].Processes
+
+		for processIdx := range processes {
+			// processes taking their limits from a default profile that does not exist are only reported once
+			if profileError != nil && processes[processIdx].ResourceProfile == "" {
+				processes[processIdx].unexpanded = true
+
+				continue
+			}
+
+			if !needsExpansion(&processes[processIdx], workflowProfile) {
+				continue
+			}
+
+			expanded, err := krt.expandProcess(
+				&processes[processIdx], workflowProfile, fmt.Sprintf("krt.workflows[%d].processes[%d]", workflowIdx, processIdx),
+			)
+			if err != nil {
+				totalError = errors.Join(totalError, err)
+				processes[processIdx].unexpanded = true
+
+				continue
+			}
+
+			processes[processIdx] = *expanded
+		}
+	}
+
+	return totalError
+}
+
+func needsExpansion(process *Process, workflowProfile *ProcessResourceLimits) bool {
+	return process.Extends != "" || process.ResourceProfile != "" || workflowProfile != nil
+}
+
+// expandProcess returns a copy of the process merged with the templates it extends and the resource profile
+// it uses, with defaults applied. Errors are located in the given process location.
+func (krt *Krt) expandProcess(process *Process, workflowProfile *ProcessResourceLimits, location string) (*Process, error) {
+	expanded := cloneValue(reflect.ValueOf(process)).Interface().(*Process) //nolint:forcetypeassert // clone of a *Process
+
+	if process.Extends != "" {
+		template, err := krt.resolveTemplate(process.Extends, nil)
+		if err != nil {
+			return nil, fmt.Errorf("%w, in %s.extends", err, location)
+		}
+
+		mergeValues(reflect.ValueOf(expanded).Elem(), reflect.ValueOf(template).Elem())
+		expanded.Extends = process.Extends
+	}
+
+	if err := krt.applyResourceProfile(expanded, workflowProfile, location); err != nil {
+		return nil, err
+	}
+
+	defaults.MustSet(expanded)
+
+	return expanded, nil
+}
+
+// resolveTemplate returns the template merged with the templates it extends, as a new process.
+func (krt *Krt) resolveTemplate(name string, chain []string) (*Process, error) {
+	for _, visited := range chain {
+		if visited == name {
+			return nil, errors.ProcessTemplateCycleError(strings.Join(append(chain, name), " -> "))
+		}
+	}
+
+	template, ok := krt.ProcessTemplates[name]
+	if !ok {
+		return nil, errors.ProcessTemplateNotFoundError(name)
+	}
+
+	resolved := cloneValue(reflect.ValueOf(&template)).Interface().(*Process) //nolint:forcetypeassert // clone of a *Process
+	if template.Extends == "" {
+		return resolved, nil
+	}
+
+	parent, err := krt.resolveTemplate(template.Extends, append(chain, name))
+	if err != nil {
+		return nil, err
+	}
+
+	mergeValues(reflect.ValueOf(resolved).Elem(), reflect.ValueOf(parent).Elem())
+	resolved.Extends = ""
+
+	return resolved, nil
+}
+
+// ValidateProcessTemplates checks the template names and that every template they extend exists
+// without cycles. Templates are not validated themselves, only the processes extending them.
+func (krt *Krt) ValidateProcessTemplates() error {
+	var totalError error
+
+	for _, name := range sortedTemplateNames(krt.ProcessTemplates) {
+		location := fmt.Sprintf("krt.processTemplates.%s", name)
+		totalError = errors.Join(totalError, validateName(name, location))
+
+		if _, err := krt.resolveTemplate(name, nil); err != nil {
+			totalError = errors.Join(totalError, fmt.Errorf("%w, in %s.extends", err, location))
+		}
+	}
+
+	return totalError
+}
+
+// withExpandedProcesses returns a shallow copy of the KRT with the processes extending a template or using
+// a resource profile expanded, so they are validated as they will run. Other processes are the same, not copies.
+func (krt *Krt) withExpandedProcesses() (*Krt, error) {
+	expanded := *krt
+	expanded.Workflows = make([]Workflow, len(krt.Workflows))
+
+	for workflowIdx, workflow := range krt.Workflows {
+		expanded.Workflows[workflowIdx] = workflow
+		expanded.Workflows[workflowIdx].Processes = append([]Process(nil), workflow.Processes...)
+	}
+
+	return &expanded, krt.expandWorkflows(expanded.Workflows)
+}
+
+func sortedTemplateNames(templates map[string]Process) []string {
+	names := make([]string, 0, len(templates))
+	for name := range templates {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// mergeValues sets the unset parts of dst from src, as described in Normalize.
+func mergeValues(dst, src reflect.Value) {
+	switch dst.Kind() {
+	case reflect.Struct:
+		for idx := 0; idx < dst.NumField(); idx++ {
+			if dst.Field(idx).CanSet() {
+				mergeValues(dst.Field(idx), src.Field(idx))
+			}
+		}
+	case reflect.Pointer:
+		switch {
+		case src.IsNil():
+		case dst.IsNil():
+			dst.Set(cloneValue(src))
+		case dst.Elem().Kind() == reflect.Struct:
+			mergeValues(dst.Elem(), src.Elem())
+		}
+	case reflect.Map:
+		if src.Len() == 0 {
+			return
+		}
+
+		if dst.IsNil() {
+			dst.Set(reflect.MakeMapWithSize(dst.Type(), src.Len()))
+		}
+
+		iter := src.MapRange()
+		for iter.Next() {
+			if !dst.MapIndex(iter.Key()).IsValid() {
+				dst.SetMapIndex(iter.Key(), cloneValue(iter.Value()))
+			}
+		}
+	case reflect.Slice:
+		if dst.Len() == 0 && src.Len() > 0 {
+			dst.Set(cloneValue(src))
+		}
+	default:
+		if dst.IsZero() {
+			dst.Set(src)
+		}
+	}
+}
+
+// cloneValue returns a deep copy of the value, so no pointer, map or slice is shared with the original.
+func cloneValue(value reflect.Value) reflect.Value {
+	switch value.Kind() {
+	case reflect.Pointer:
+		if value.IsNil() {
+			return reflect.Zero(value.Type())
+		}
+
+		clone := reflect.New(value.Type().Elem())
+		clone.Elem().Set(cloneValue(value.Elem()))
+
+		return clone
+	case reflect.Struct:
+		clone := reflect.New(value.Type()).Elem()
+
+		for idx := 0; idx < value.NumField(); idx++ {
+			if clone.Field(idx).CanSet() {
+				clone.Field(idx).Set(cloneValue(value.Field(idx)))
+			}
+		}
+
+		return clone
+	case reflect.Map:
+		if value.IsNil() {
+			return reflect.Zero(value.Type())
+		}
+
+		clone := reflect.MakeMapWithSize(value.Type(), value.Len())
+
+		iter := value.MapRange()
+		for iter.Next() {
+			clone.SetMapIndex(iter.Key(), cloneValue(iter.Value()))
+		}
+
+		return clone
+	case reflect.Slice:
+		if value.IsNil() {
+			return reflect.Zero(value.Type())
+		}
+
+		clone := reflect.MakeSlice(value.Type(), value.Len(), value.Len())
+		for idx := 0; idx < value.Len(); idx++ {
+			clone.Index(idx).Set(cloneValue(value.Index(idx)))
+		}
+
+		return clone
+	default:
+		return value
+	}
+}
@@ -0,0 +1,184 @@
+//go:build unit
+
+package krt_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/konstellation-io/krt/pkg/errors"
+	"github.com/konstellation-io/krt/pkg/krt"
+)
+
+func templatesKrt() *KrtBuilder {
+	replicas := 3
+
+	return NewKrtBuilder().
+		WithProcessTemplates(map[string]krt.Process{
+			"base": {
+				Type:          krt.ProcessTypeTask,
+				Config:        map[string]string{"LOG_LEVEL": "info", "REGION": "eu"},
+				NodeSelectors: map[string]string{"pool": "default"},
+				ResourceLimits: &krt.ProcessResourceLimits{
+					CPU:    &krt.ResourceLimit{Request: "100m", Limit: "200m"},
+					Memory: &krt.ResourceLimit{Request: "100M", Limit: "200M"},
+				},
+			},
+			"worker": {
+				Extends:       "base",
+				Replicas:      &replicas,
+				NodeSelectors: map[string]string{"pool": "workers"},
+				Subscriptions: []string{"test-trigger"},
+			},
+		}).
+		WithProcess(krt.Process{
+			Name:    "worker",
+			Extends: "worker",
+			Image:   "worker-image",
+			Config:  map[string]string{"LOG_LEVEL": "debug"},
+			ResourceLimits: &krt.ProcessResourceLimits{
+				CPU: &krt.ResourceLimit{Request: "500m", Limit: "1"},
+			},
+		}).
+		WithProcessSubscriptions([]string{"worker"}, 1)
+}
+
+func TestKrt_Normalize(t *testing.T) {
+	krtYaml := templatesKrt().Build()
+
+	normalized, err := krtYaml.Normalize()
+	require.NoError(t, err)
+
+	assert.Nil(t, normalized.ProcessTemplates)
+
+	worker := normalized.Workflows[0].Processes[2]
+	assert.Equal(t, "worker", worker.Name)
+	assert.Empty(t, worker.Extends)
+	assert.Equal(t, krt.ProcessTypeTask, worker.Type)
+	assert.Equal(t, "worker-image", worker.Image)
+	assert.Equal(t, 3, *worker.Replicas)
+	assert.False(t, *worker.GPU, "defaults are applied to expanded processes")
+	assert.Equal(t, map[string]string{"LOG_LEVEL": "debug", "REGION": "eu"}, worker.Config)
+	assert.Equal(t, map[string]string{"pool": "workers"}, worker.NodeSelectors)
+	assert.Equal(t, &krt.ResourceLimit{Request: "500m", Limit: "1"}, worker.ResourceLimits.CPU)
+	assert.Equal(t, &krt.ResourceLimit{Request: "100M", Limit: "200M"}, worker.ResourceLimits.Memory)
+	assert.Equal(t, []string{"test-trigger"}, worker.Subscriptions)
+
+	original := krtYaml.Workflows[0].Processes[2]
+	assert.Equal(t, "worker", original.Extends, "the original KRT is not modified")
+	assert.Nil(t, original.ResourceLimits.Memory)
+
+	normalized.ProcessTemplates = map[string]krt.Process{}
+	normalized.Workflows[0].Processes[0].Config = map[string]string{"KEY": "value"}
+	assert.Nil(t, krtYaml.Workflows[0].Processes[0].Config, "the copy does not share data with the original")
+}
+
+func TestKrt_ExpandedProcess(t *testing.T) {
+	krtYaml := templatesKrt().Build()
+
+	worker, err := krtYaml.ExpandedProcess("test-workflow", "worker")
+	require.NoError(t, err)
+	assert.Equal(t, 3, *worker.Replicas)
+
+	trigger, err := krtYaml.ExpandedProcess("test-workflow", "test-trigger")
+	require.NoError(t, err)
+	assert.Same(t, &krtYaml.Workflows[0].Processes[0], trigger)
+}
+
+func TestKrt_EffectiveConfig_ProcessTemplates(t *testing.T) {
+	krtYaml := templatesKrt().Build()
+
+	config, err := krtYaml.EffectiveConfig("test-workflow", "worker")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"LOG_LEVEL": "debug", "REGION": "eu"}, config.Env())
+	assert.Equal(t, krt.ConfigSourceProcess, config["REGION"].Source, "template config is process config")
+}
+
+func TestKrt_Validate_ProcessTemplates(t *testing.T) {
+	testCases := []struct {
+		name        string
+		krtYaml     *krt.Krt
+		errorType   error
+		errorString string
+		errorCount  int
+	}{
+		{
+			name:        "valid process extending a template",
+			krtYaml:     templatesKrt().Build(),
+			errorType:   nil,
+			errorString: "",
+		},
+		{
+			name:        "process extending a non existent template",
+			krtYaml:     NewKrtBuilder().WithProcess(krt.Process{Name: "worker", Extends: "non-existent"}).Build(),
+			errorType:   errors.ErrProcessTemplateNotFound,
+			errorString: `process template not found: "non-existent", in krt.workflows[0].processes[2].extends`,
+			errorCount:  1,
+		},
+		{
+			name: "templates extending each other",
+			krtYaml: NewKrtBuilder().WithProcessTemplates(map[string]krt.Process{
+				"first":  {Extends: "second"},
+				"second": {Extends: "first"},
+			}).Build(),
+			errorType:   errors.ErrProcessTemplateCycle,
+			errorString: "first -> second -> first, in krt.processTemplates.first.extends",
+		},
+		{
+			name:        "template with an invalid name",
+			krtYaml:     NewKrtBuilder().WithProcessTemplates(map[string]krt.Process{"Invalid": {}}).Build(),
+			errorType:   errors.ErrInvalidFieldName,
+			errorString: errors.InvalidFieldNameError("krt.processTemplates.Invalid").Error(),
+		},
+		{
+			name: "expanded process with an invalid field from the template",
+			krtYaml: templatesKrt().WithProcessTemplates(map[string]krt.Process{
+				"worker": {Type: "invalid", Subscriptions: []string{"test-trigger"}},
+			}).Build(),
+			errorType: errors.ErrInvalidProcessType,
+			errorString: errors.InvalidProcessTypeError("krt.workflows[0].processes[2].type").Error() +
+				`, in a process extending template "worker"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.krtYaml.Validate()
+			if tc.errorType == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.ErrorIs(t, err, tc.errorType)
+			assert.ErrorContains(t, err, tc.errorString)
+
+			if tc.errorCount > 0 {
+				assert.Len(t, strings.Split(err.Error(), "\n"), tc.errorCount)
+			}
+		})
+	}
+}
+
+func TestKrt_Validate_ProcessTemplates_CompletesLimits(t *testing.T) {
+	krtYaml := templatesKrt().Build()
+	krtYaml.Workflows[0].Processes[2].ResourceLimits.CPU.Limit = ""
+
+	require.NoError(t, krtYaml.Validate())
+	assert.Equal(t, &krt.ResourceLimit{Request: "500m", Limit: "500m"}, krtYaml.Workflows[0].Processes[2].ResourceLimits.CPU,
+		"missing limits are set to the request in processes extending a template too")
+}
+
+func TestKrt_Validate_ProcessTemplates_ErrorWithCause(t *testing.T) {
+	krtYaml := NewKrtBuilder().WithProcessTemplates(map[string]krt.Process{
+		"cron": {Trigger: &krt.ProcessTrigger{Kind: krt.TriggerKindCron, Cron: &krt.CronTrigger{Expression: "* * * *"}}},
+	}).Build()
+	krtYaml.Workflows[0].Processes[0].Extends = "cron"
+
+	err := krtYaml.Validate()
+	require.Error(t, err)
+	assert.ErrorIs(t, err, errors.ErrInvalidCronExpression)
+	assert.Equal(t, 1, strings.Count(err.Error(), `, in a process extending template "cron"`), "the cause is not split apart")
+}
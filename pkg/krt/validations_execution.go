@@ -0,0 +1,153 @@
+package krt
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/konstellation-io/krt/pkg/errors"
+)
+
+func (process *Process) ValidateTimeout(workflowIdx, processIdx int) error {
+	if process.Timeout == "" {
+		return nil
+	}
+
+	return validateDuration(process.Timeout, fmt.Sprintf("krt.workflows[%d].processes[%d].timeout", workflowIdx, processIdx))
+}
+
+func (process *Process) ValidateRetry(workflowIdx, processIdx int) error {
+	if process.Retry == nil {
+		return nil
+	}
+
+	location := fmt.Sprintf("krt.workflows[%d].processes[%d].retry", workflowIdx, processIdx)
+
+	if process.Type == ProcessTypeTrigger {
+		return errors.RetryNotAllowedError(string(process.Type), location)
+	}
+
+	return validateRetryPolicy(process.Retry, location)
+}
+
+func (workflow *Workflow) ValidateDefaults(workflowIdx int) error {
+	if workflow.Defaults == nil {
+		return nil
+	}
+
+	var totalError error
+
+	location := fmt.Sprintf("krt.workflows[%d].defaults", workflowIdx)
+
+	if workflow.Defaults.Timeout != "" {
+		totalError = errors.Join(totalError, validateDuration(workflow.Defaults.Timeout, location+".timeout"))
+	}
+
+	if workflow.Defaults.Retry != nil {
+		totalError = errors.Join(totalError, validateRetryPolicy(workflow.Defaults.Retry, location+".retry"))
+	}
+
+	return totalError
+}
+
+func validateRetryPolicy(retry *RetryPolicy, location string) error {
+	var totalError error
+
+	if retry.MaxAttempts == nil {
+		totalError = errors.Join(totalError, errors.MissingRequiredFieldError(location+".maxAttempts"))
+	} else {
+		totalError = errors.Join(totalError, validatePositive(retry.MaxAttempts, location+".maxAttempts"))
+	}
+
+	if retry.Backoff != nil {
+		totalError = errors.Join(totalError, validateRetryBackoff(retry.Backoff, location+".backoff"))
+	}
+
+	return totalError
+}
+
+func validateRetryBackoff(backoff *RetryBackoff, location string) error {
+	totalError := errors.Join(
+		validateDuration(backoff.Initial, location+".initial"),
+		validateDuration(backoff.Max, location+".max"),
+	)
+
+	if totalError == nil && mustParseDuration(backoff.Max) < mustParseDuration(backoff.Initial) {
+		totalError = errors.InvalidBackoffError("max wait cannot be lower than the initial wait", location+".max")
+	}
+
+	if backoff.Multiplier != nil && *backoff.Multiplier < 1 {
+		totalError = errors.Join(
+			totalError,
+			errors.InvalidBackoffError("multiplier cannot be lower than 1", location+".multiplier"),
+		)
+	}
+
+	return totalError
+}
+
+// validateExitTimeouts checks no exit process waits longer than a trigger of the workflow, as the trigger
+// would have given up on the response by then.
+func validateExitTimeouts(workflow *Workflow, workflowIdx int) error {
+	var totalError error
+
+	for exitIdx := range workflow.Processes {
+		exit := &workflow.Processes[exitIdx]
+		if exit.Type != ProcessTypeExit {
+			continue
+		}
+
+		exitTimeout, ok := parseTimeout(workflow.EffectiveTimeout(exit))
+		if !ok {
+			continue
+		}
+
+		for triggerIdx := range workflow.Processes {
+			trigger := &workflow.Processes[triggerIdx]
+			if trigger.Type != ProcessTypeTrigger {
+				continue
+			}
+
+			triggerTimeout, ok := parseTimeout(workflow.EffectiveTimeout(trigger))
+			if !ok || exitTimeout <= triggerTimeout {
+				continue
+			}
+
+			totalError = errors.Join(totalError, errors.TimeoutExceedsTriggerError(
+				trigger.Name,
+				timeoutLocation(exit, workflowIdx, exitIdx),
+				timeoutLocation(trigger, workflowIdx, triggerIdx),
+			))
+		}
+	}
+
+	return totalError
+}
+
+// timeoutLocation returns where the effective timeout of a process is declared, the workflow defaults
+// for processes without their own.
+func timeoutLocation(process *Process, workflowIdx, processIdx int) string {
+	if process.Timeout == "" {
+		return fmt.Sprintf("krt.workflows[%d].defaults.timeout", workflowIdx)
+	}
+
+	return fmt.Sprintf("krt.workflows[%d].processes[%d].timeout", workflowIdx, processIdx)
+}
+
+// parseTimeout parses a timeout, ignoring empty and invalid ones as they are reported elsewhere.
+func parseTimeout(timeout string) (time.Duration, bool) {
+	if timeout == "" {
+		return 0, false
+	}
+
+	parsedTimeout, err := time.ParseDuration(timeout)
+	if err != nil || parsedTimeout <= 0 {
+		return 0, false
+	}
+
+	return parsedTimeout, true
+}
+
+func mustParseDuration(duration string) time.Duration {
+	parsedDuration, _ := time.ParseDuration(duration)
+	return parsedDuration
+}
@@ -1,16 +1,22 @@
 package krt
 
 import (
+	"fmt"
 	"regexp"
+	"sort"
+	"strings"
+	"time"
 
+	"github.com/konstellation-io/krt/internal/kubeutil"
 	"github.com/konstellation-io/krt/pkg/errors"
 )
 
 const MaxFieldNameLength = 20
 
-func isValidVersion(version string) bool {
-	reVersion := regexp.MustCompile(`^v\d+\.\d+\.\d+$`)
-	return reVersion.MatchString(version)
+// ReservedConfigKeyPrefixes returns the config and secret key prefixes used by the runtime.
+// Keys starting with any of them, regardless of the case, are rejected.
+func ReservedConfigKeyPrefixes() []string {
+	return []string{"KAI_"}
 }
 
 func isValidResourceName(name string) bool {
@@ -18,15 +24,24 @@ func isValidResourceName(name string) bool {
 	return reResourceName.MatchString(name)
 }
 
-func validateVersion(version, versionLocation string) error {
+func validateVersion(version, versionLocation string, policy VersionPolicy) error {
+	if policy != VersionPolicyStrict && policy != VersionPolicySemVer {
+		return errors.UnknownVersionPolicyError(string(policy))
+	}
+
 	if version == "" {
 		return errors.MissingRequiredFieldError(versionLocation)
 	}
 
-	if !isValidVersion(version) {
+	parsedVersion, err := ParseVersion(version)
+	if err != nil {
 		return errors.InvalidVersionTagError(versionLocation)
 	}
 
+	if policy == VersionPolicyStrict && (parsedVersion.IsPrerelease() || len(parsedVersion.Build) > 0) {
+		return errors.VersionPrereleaseNotAllowedError(versionLocation)
+	}
+
 	return nil
 }
 
@@ -45,3 +60,155 @@ func validateName(name, nameLocation string) error {
 
 	return nil
 }
+
+func validateDuration(duration, durationLocation string) error {
+	parsedDuration, err := time.ParseDuration(duration)
+	if err != nil || parsedDuration <= 0 {
+		return errors.InvalidDurationError(durationLocation)
+	}
+
+	return nil
+}
+
+func validatePositive(value *int, valueLocation string) error {
+	if value != nil && *value < 1 {
+		return errors.ValueMustBePositiveError(valueLocation)
+	}
+
+	return nil
+}
+
+func validateNonNegative(value *int, valueLocation string) error {
+	if value != nil && *value < 0 {
+		return errors.ValueMustNotBeNegativeError(valueLocation)
+	}
+
+	return nil
+}
+
+func isValidConfigKey(key string) bool {
+	reConfigKey := regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	return reConfigKey.MatchString(key)
+}
+
+func hasReservedPrefix(key string) bool {
+	for _, prefix := range ReservedConfigKeyPrefixes() {
+		if strings.HasPrefix(strings.ToUpper(key), strings.ToUpper(prefix)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func validateConfigKey(key, keyLocation string) error {
+	if !isValidConfigKey(key) {
+		return errors.InvalidConfigKeyError(keyLocation)
+	}
+
+	if hasReservedPrefix(key) {
+		return errors.ReservedConfigKeyError(keyLocation)
+	}
+
+	return nil
+}
+
+// validateConfig checks every key of a config map, reporting them in alphabetical order.
+func validateConfig(config map[string]string, configLocation string) error {
+	var totalError error
+
+	for _, key := range sortedKeys(config) {
+		totalError = errors.Join(totalError, validateConfigKey(key, fmt.Sprintf("%s.%s", configLocation, key)))
+	}
+
+	return totalError
+}
+
+// validateLabels checks every label follows the Kubernetes label rules, reporting them in alphabetical order.
+func validateLabels(labels map[string]string, labelsLocation string) error {
+	var totalError error
+
+	for _, key := range sortedKeys(labels) {
+		if err := kubeutil.ValidateLabelKey(key); err != nil {
+			totalError = errors.Join(totalError, fmt.Errorf("%s: invalid key %q: %w", labelsLocation, key, err))
+		}
+
+		if err := kubeutil.ValidateLabelValue(labels[key]); err != nil {
+			totalError = errors.Join(totalError, fmt.Errorf("%s.%s: invalid value %q: %w", labelsLocation, key, labels[key], err))
+		}
+	}
+
+	return totalError
+}
+
+// validateAnnotations checks every annotation key follows the Kubernetes annotation rules.
+// Annotation values can be any string, only their total size is limited.
+func validateAnnotations(annotations map[string]string, annotationsLocation string) error {
+	var totalError error
+
+	for _, key := range sortedKeys(annotations) {
+		if err := kubeutil.ValidateAnnotationKey(key); err != nil {
+			totalError = errors.Join(totalError, fmt.Errorf("%s: invalid key %q: %w", annotationsLocation, key, err))
+		}
+	}
+
+	if err := kubeutil.ValidateAnnotationsSize(annotations); err != nil {
+		totalError = errors.Join(totalError, fmt.Errorf("%s: %w", annotationsLocation, err))
+	}
+
+	return totalError
+}
+
+func validateMetadata(labels, annotations map[string]string, location string) error {
+	return errors.Join(
+		validateLabels(labels, location+".labels"),
+		validateAnnotations(annotations, location+".annotations"),
+	)
+}
+
+// configKeyDeclaration is a config key as it was written and where.
+type configKeyDeclaration struct {
+	key      string
+	location string
+}
+
+// checkConfigKeyConflicts reports keys of a config map that differ only in case from a key
+// already declared, either in the same map or in the declarations given.
+//
+// It returns the declarations given extended with the keys of the config map.
+func checkConfigKeyConflicts(
+	config map[string]string,
+	configLocation string,
+	declared map[string]configKeyDeclaration,
+) (map[string]configKeyDeclaration, error) {
+	var totalError error
+
+	declarations := make(map[string]configKeyDeclaration, len(declared)+len(config))
+	for foldedKey, declaration := range declared {
+		declarations[foldedKey] = declaration
+	}
+
+	for _, key := range sortedKeys(config) {
+		location := fmt.Sprintf("%s.%s", configLocation, key)
+		foldedKey := strings.ToUpper(key)
+
+		if previous, ok := declarations[foldedKey]; ok && previous.key != key {
+			totalError = errors.Join(totalError, errors.ConflictingConfigKeyError(location, previous.location))
+		}
+
+		declarations[foldedKey] = configKeyDeclaration{key: key, location: location}
+	}
+
+	return declarations, totalError
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -1,16 +1,107 @@
 package krt
 
-import "github.com/konstellation-io/krt/pkg/errors"
+import (
+	"fmt"
+	"strings"
+
+	"github.com/konstellation-io/krt/pkg/errors"
+)
+
+// ValidateOption customizes how a KRT is validated.
+type ValidateOption func(*validateOptions)
+
+type validateOptions struct {
+	versionPolicy     VersionPolicy
+	unprivilegedPorts bool
+	strictSubtopics   bool
+}
+
+// WithVersionPolicy sets the versions accepted, DefaultVersionPolicy is used if not set.
+func WithVersionPolicy(policy VersionPolicy) ValidateOption {
+	return func(opts *validateOptions) {
+		opts.versionPolicy = policy
+	}
+}
+
+// WithUnprivilegedPorts rejects ports lower than MinUnprivilegedPort, see ValidateUnprivilegedPorts.
+func WithUnprivilegedPorts() ValidateOption {
+	return func(opts *validateOptions) {
+		opts.unprivilegedPorts = true
+	}
+}
+
+// WithStrictSubtopics rejects subtopics no process subscribes to, see ValidateUnusedSubtopics.
+func WithStrictSubtopics() ValidateOption {
+	return func(opts *validateOptions) {
+		opts.strictSubtopics = true
+	}
+}
+
+func (krt *Krt) Validate(opts ...ValidateOption) error {
+	options := validateOptions{versionPolicy: DefaultVersionPolicy}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	// processes extending a template or using a resource profile are validated as they will run, on a copy,
+	// so the missing limits are set to the request in the KRT itself
+	krt.completeResourceLimits()
+	expanded, expandError := krt.withExpandedProcesses()
+
+	var portsError, subtopicsError error
+	if options.unprivilegedPorts {
+		portsError = expanded.ValidateUnprivilegedPorts()
+	}
+
+	if options.strictSubtopics {
+		subtopicsError = expanded.ValidateUnusedSubtopics()
+	}
 
-func (krt *Krt) Validate() error {
 	return errors.Join(
+		krt.ValidateAPIVersion(),
 		krt.ValidateDescription(),
-		krt.ValidateKRTVersion(),
+		krt.validateKRTVersion(options.versionPolicy),
 		krt.ValidateVersionConfig(),
-		krt.ValidateWorkflows(),
+		krt.ValidateMetadata(),
+		krt.ValidateResourceProfiles(),
+		krt.ValidateProcessTemplates(),
+		expandError,
+		expanded.ValidateWorkflows(),
+		expanded.ValidateConfigConflicts(),
+		expanded.ValidateIngressRoutes(),
+		expanded.ValidateCrossWorkflowSubscriptions(),
+		portsError,
+		subtopicsError,
 	)
 }
 
+// completeResourceLimits sets the resource limits processes leave unset to their request.
+func (krt *Krt) completeResourceLimits() {
+	for workflowIdx := range krt.Workflows {
+		for _, process := range krt.Workflows[workflowIdx].Processes {
+			if process.ResourceLimits == nil {
+				continue
+			}
+
+			for _, resourceLimit := range []*ResourceLimit{process.ResourceLimits.CPU, process.ResourceLimits.Memory} {
+				if resourceLimit != nil && resourceLimit.Limit == "" {
+					resourceLimit.Limit = resourceLimit.Request
+				}
+			}
+		}
+	}
+}
+
+// ValidateAPIVersion checks the KRT uses the current API version. Older documents must be migrated
+// first, which parse does automatically. KRTs built in code without API version are accepted.
+func (krt *Krt) ValidateAPIVersion() error {
+	if krt.APIVersion != "" && krt.APIVersion != CurrentAPIVersion {
+		return errors.UnsupportedAPIVersionError(krt.APIVersion, "krt.apiVersion")
+	}
+
+	return nil
+}
+
 func (krt *Krt) ValidateDescription() error {
 	if krt.Description == "" {
 		return errors.MissingRequiredFieldError("krt.description")
@@ -20,11 +111,19 @@ func (krt *Krt) ValidateDescription() error {
 }
 
 func (krt *Krt) ValidateKRTVersion() error {
-	return validateVersion(krt.Version, "krt.version")
+	return krt.validateKRTVersion(DefaultVersionPolicy)
+}
+
+func (krt *Krt) validateKRTVersion(policy VersionPolicy) error {
+	return validateVersion(krt.Version, "krt.version", policy)
 }
 
 func (krt *Krt) ValidateVersionConfig() error {
-	return nil
+	return validateConfig(krt.Config, "krt.config")
+}
+
+func (krt *Krt) ValidateMetadata() error {
+	return validateMetadata(krt.Labels, krt.Annotations, "krt")
 }
 
 func (krt *Krt) ValidateWorkflows() error {
@@ -41,9 +140,204 @@ func (krt *Krt) ValidateWorkflows() error {
 
 		for idx, workflow := range krt.Workflows {
 			err := workflow.Validate(idx)
+			if err != nil && workflow.File != "" {
+				err = errors.IncludedWorkflowError(err, workflow.File)
+			}
+
 			totalError = errors.Join(totalError, err)
 		}
 	}
 
 	return totalError
 }
+
+// ValidateConfigConflicts checks that no process ends up with config keys that differ only in case,
+// whether they are declared in the product, workflow or process config.
+func (krt *Krt) ValidateConfigConflicts() error {
+	productKeys, totalError := checkConfigKeyConflicts(krt.Config, "krt.config", nil)
+
+	for workflowIdx, workflow := range krt.Workflows {
+		workflowKeys, err := checkConfigKeyConflicts(
+			workflow.Config,
+			fmt.Sprintf("krt.workflows[%d].config", workflowIdx),
+			productKeys,
+		)
+		totalError = errors.Join(totalError, err)
+
+		for processIdx, process := range workflow.Processes {
+			_, err := checkConfigKeyConflicts(
+				process.Config,
+				fmt.Sprintf("krt.workflows[%d].processes[%d].config", workflowIdx, processIdx),
+				workflowKeys,
+			)
+			totalError = errors.Join(totalError, err)
+		}
+	}
+
+	return totalError
+}
+
+// ValidateIngressRoutes checks that no two processes are exposed with the same host and path prefix,
+// regardless of the workflow they belong to.
+func (krt *Krt) ValidateIngressRoutes() error {
+	var totalError error
+
+	routes := make(map[string]string)
+
+	for workflowIdx, workflow := range krt.Workflows {
+		for processIdx, process := range workflow.Processes {
+			if process.Ingress == nil {
+				continue
+			}
+
+			route := ingressRoute(process.Ingress)
+			location := fmt.Sprintf("krt.workflows[%d].processes[%d].ingress", workflowIdx, processIdx)
+
+			if previousLocation, ok := routes[route]; ok {
+				totalError = errors.Join(totalError, errors.DuplicatedIngressRouteError(route, location, previousLocation))
+
+				continue
+			}
+
+			routes[route] = location
+		}
+	}
+
+	return totalError
+}
+
+func ingressRoute(ingress *ProcessIngress) string {
+	pathPrefix := strings.TrimSuffix(ingress.PathPrefix, "/")
+	if pathPrefix == "" {
+		pathPrefix = "/"
+	}
+
+	return strings.ToLower(ingress.Host) + pathPrefix
+}
+
+// ValidateCrossWorkflowSubscriptions checks subscriptions qualified with the name of another workflow,
+// such as "serving/exitpoint", against the processes of that workflow.
+//
+// Besides the rules that apply to any subscription, the type of the subscribing workflow must be
+// allowed to subscribe to the type of the subscribed workflow.
+func (krt *Krt) ValidateCrossWorkflowSubscriptions() error {
+	var totalError error
+
+	workflowsByNames := make(map[string]*Workflow, len(krt.Workflows))
+	for idx := range krt.Workflows {
+		workflowsByNames[krt.Workflows[idx].Name] = &krt.Workflows[idx]
+	}
+
+	for workflowIdx, workflow := range krt.Workflows {
+		for processIdx, process := range workflow.Processes {
+			for _, subscription := range process.Subscriptions {
+				reference := parseSubscription(subscription)
+				if !reference.isCrossWorkflow(workflow.Name) {
+					continue
+				}
+
+				totalError = errors.Join(totalError, checkCrossWorkflowSubscription(
+					&workflow, &process, subscription, reference, workflowsByNames, workflowIdx, processIdx,
+				))
+			}
+		}
+	}
+
+	return totalError
+}
+
+func checkCrossWorkflowSubscription(
+	workflow *Workflow,
+	process *Process,
+	subscription string,
+	reference subscriptionReference,
+	workflowsByNames map[string]*Workflow,
+	workflowIdx, processIdx int,
+) error {
+	location := fmt.Sprintf(subscriptionLocation, workflowIdx, processIdx, subscription)
+
+	subscribedWorkflow, ok := workflowsByNames[reference.workflow]
+	if !ok {
+		return errors.CannotSubscribeToNonExistentWorkflowError(reference.workflow, location)
+	}
+
+	var subscribedProcess *Process
+
+	for idx := range subscribedWorkflow.Processes {
+		if subscribedWorkflow.Processes[idx].Name == reference.process {
+			subscribedProcess = &subscribedWorkflow.Processes[idx]
+		}
+	}
+
+	if subscribedProcess == nil {
+		return errors.CannotSubscribeToNonExistentProcessError(fmt.Sprintf("%s/%s", reference.workflow, reference.process), location)
+	}
+
+	var totalError error
+
+	if !canSubscribeToWorkflow(workflow.Type, subscribedWorkflow.Type) {
+		totalError = errors.Join(totalError, errors.InvalidCrossWorkflowSubscriptionError(
+			string(workflow.Type), string(subscribedWorkflow.Type), location,
+		))
+	}
+
+	if !isValidSubscription(process, subscribedProcess) {
+		totalError = errors.Join(totalError, errors.InvalidProcessSubscriptionError(
+			process.kind(), subscribedProcess.kind(), location,
+		))
+	}
+
+	processesByNames := make(map[string]*Process, len(workflow.Processes))
+	for idx := range workflow.Processes {
+		processesByNames[workflow.Processes[idx].Name] = &workflow.Processes[idx]
+	}
+
+	return errors.Join(
+		totalError,
+		checkSubscriptionSubtopic(reference, subscribedProcess, location),
+		checkDeadLetter(
+			process, subscription, subscribedProcess, processesByNames,
+			fmt.Sprintf(subscriptionSettingsLocation, workflowIdx, processIdx, subscription),
+		),
+	)
+}
+
+// ValidateUnusedSubtopics reports subtopics declared by a process that no process subscribes to,
+// either from the same workflow or from another one.
+//
+// Shipping a producer before its consumers is a common rollout, so they are only part of Validate
+// with WithStrictSubtopics. Otherwise they can be reported as warnings calling it on its own.
+func (krt *Krt) ValidateUnusedSubtopics() error {
+	var totalError error
+
+	subscribedSubtopics := make(map[string]bool)
+
+	for _, workflow := range krt.Workflows {
+		for _, process := range workflow.Processes {
+			for _, subscription := range process.Subscriptions {
+				reference := parseSubscription(subscription)
+				if reference.workflow == "" {
+					reference.workflow = workflow.Name
+				}
+
+				subscribedSubtopics[reference.String()] = true
+			}
+		}
+	}
+
+	for workflowIdx, workflow := range krt.Workflows {
+		for processIdx, process := range workflow.Processes {
+			for subtopicIdx, subtopic := range process.Subtopics {
+				reference := subscriptionReference{workflow: workflow.Name, process: process.Name, subtopic: subtopic}
+
+				if !subscribedSubtopics[reference.String()] {
+					totalError = errors.Join(totalError, errors.UnusedSubtopicError(
+						fmt.Sprintf("krt.workflows[%d].processes[%d].subtopics[%d]", workflowIdx, processIdx, subtopicIdx),
+					))
+				}
+			}
+		}
+	}
+
+	return totalError
+}
@@ -2,6 +2,7 @@ package krt
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/konstellation-io/krt/internal/kubeutil"
@@ -10,20 +11,36 @@ import (
 
 const subscriptionLocation = "krt.workflows[%d].processes[%d].subscriptions.%s"
 
+const subscriptionSettingsLocation = "krt.workflows[%d].processes[%d].subscriptionSettings.%s"
+
+const (
+	MinPort             = 1
+	MaxPort             = 65535
+	MinUnprivilegedPort = 1024
+)
+
 func (process *Process) Validate(workflowIdx, processIdx int) error {
 	return errors.Join(
 		process.ValidateName(workflowIdx, processIdx),
 		process.ValidateType(workflowIdx, processIdx),
 		process.ValidateImage(workflowIdx, processIdx),
 		process.ValidateReplicas(workflowIdx, processIdx),
+		process.ValidateMode(workflowIdx, processIdx),
 		process.ValidateGPU(workflowIdx, processIdx),
 		process.ValidateConfig(workflowIdx, processIdx),
 		process.ValidateObjectStore(workflowIdx, processIdx),
 		process.ValidateSecrets(workflowIdx, processIdx),
 		process.ValidateSubscriptions(workflowIdx, processIdx),
+		process.ValidateSubtopics(workflowIdx, processIdx),
+		process.ValidateJoin(workflowIdx, processIdx),
+		process.ValidateTimeout(workflowIdx, processIdx),
+		process.ValidateRetry(workflowIdx, processIdx),
 		process.ValidateNetworking(workflowIdx, processIdx),
 		process.ValidateResourceLimits(workflowIdx, processIdx),
 		process.ValidateNodeSelectors(workflowIdx, processIdx),
+		process.ValidateMetadata(workflowIdx, processIdx),
+		process.ValidateIngress(workflowIdx, processIdx),
+		process.ValidateTrigger(workflowIdx, processIdx),
 	)
 }
 
@@ -63,7 +80,7 @@ func (process *Process) ValidateGPU(workflowIdx, processIdx int) error {
 }
 
 func (process *Process) ValidateConfig(workflowIdx, processIdx int) error {
-	return nil
+	return validateConfig(process.Config, fmt.Sprintf("krt.workflows[%d].processes[%d].config", workflowIdx, processIdx))
 }
 
 func (process *Process) ValidateObjectStore(workflowIdx, processIdx int) error {
@@ -100,15 +117,20 @@ func (process *Process) ValidateObjectStore(workflowIdx, processIdx int) error {
 }
 
 func (process *Process) ValidateSecrets(workflowIdx, processIdx int) error {
-	return nil
-}
+	var totalError error
 
-func (process *Process) ValidateSubscriptions(workflowIdx, processIdx int) error {
-	if process.Type == ProcessTypeTrigger {
-		return nil
+	for _, secret := range process.Secrets {
+		totalError = errors.Join(
+			totalError,
+			validateConfigKey(secret, fmt.Sprintf("krt.workflows[%d].processes[%d].secrets.%s", workflowIdx, processIdx, secret)),
+		)
 	}
 
-	if process.Subscriptions == nil {
+	return totalError
+}
+
+func (process *Process) ValidateSubscriptions(workflowIdx, processIdx int) error {
+	if process.Type != ProcessTypeTrigger && process.Subscriptions == nil {
 		return errors.MissingRequiredFieldError(
 			fmt.Sprintf("krt.workflows[%d].processes[%d].subscriptions",
 				workflowIdx,
@@ -117,7 +139,142 @@ func (process *Process) ValidateSubscriptions(workflowIdx, processIdx int) error
 		)
 	}
 
-	return nil
+	var totalError error
+
+	for _, subscription := range sortedSubscriptionSettings(process.SubscriptionSettings) {
+		location := fmt.Sprintf(subscriptionSettingsLocation, workflowIdx, processIdx, subscription)
+
+		if !slices.Contains(process.Subscriptions, subscription) {
+			totalError = errors.Join(totalError, errors.UnknownSubscriptionSettingsError(location))
+
+			continue
+		}
+
+		totalError = errors.Join(totalError, process.SubscriptionSettings[subscription].validate(location))
+	}
+
+	return totalError
+}
+
+func (s SubscriptionSettings) validate(settingsLocation string) error {
+	totalError := errors.Join(
+		validatePositive(s.MaxDeliver, settingsLocation+".maxDeliver"),
+		validatePositive(s.MaxAckPending, settingsLocation+".maxAckPending"),
+	)
+
+	if s.AckWait != "" {
+		totalError = errors.Join(totalError, validateDuration(s.AckWait, settingsLocation+".ackWait"))
+	}
+
+	if s.DeadLetter != "" {
+		totalError = errors.Join(totalError, validateName(s.DeadLetter, settingsLocation+".deadLetter"))
+	}
+
+	return totalError
+}
+
+func (process *Process) ValidateSubtopics(workflowIdx, processIdx int) error {
+	var totalError error
+
+	declaredSubtopics := make(map[string]bool)
+
+	for subtopicIdx, subtopic := range process.Subtopics {
+		location := fmt.Sprintf("krt.workflows[%d].processes[%d].subtopics[%d]", workflowIdx, processIdx, subtopicIdx)
+
+		totalError = errors.Join(totalError, validateName(subtopic, location))
+
+		if declaredSubtopics[subtopic] {
+			totalError = errors.Join(totalError, errors.DuplicatedSubtopicError(location))
+		}
+
+		declaredSubtopics[subtopic] = true
+	}
+
+	return totalError
+}
+
+func (process *Process) ValidateMode(workflowIdx, processIdx int) error {
+	location := fmt.Sprintf("krt.workflows[%d].processes[%d]", workflowIdx, processIdx)
+
+	if !process.Mode.IsValid() {
+		return errors.InvalidProcessModeError(location + ".mode")
+	}
+
+	if !process.IsJob() {
+		if process.Job != nil {
+			return errors.UnexpectedJobConfigError(location + ".job")
+		}
+
+		return nil
+	}
+
+	if process.Type != ProcessTypeTask {
+		return errors.JobNotAllowedError(string(process.Type), location+".mode")
+	}
+
+	var totalError error
+
+	if len(process.Subscriptions) > 1 {
+		totalError = errors.Join(totalError, errors.InvalidJobSubscriptionsError(location+".subscriptions"))
+	}
+
+	if process.Replicas != nil && *process.Replicas > 1 {
+		totalError = errors.Join(totalError, errors.JobReplicasError(location+".replicas"))
+	}
+
+	if process.Job != nil {
+		totalError = errors.Join(
+			totalError,
+			validatePositive(process.Job.Completions, location+".job.completions"),
+			validatePositive(process.Job.Parallelism, location+".job.parallelism"),
+			validateNonNegative(process.Job.BackoffLimit, location+".job.backoffLimit"),
+		)
+	}
+
+	return totalError
+}
+
+// kind returns the type of the process as shown in errors, jobs are told apart from service tasks.
+func (process *Process) kind() string {
+	if process.IsJob() {
+		return string(ProcessModeJob)
+	}
+
+	return string(process.Type)
+}
+
+func (process *Process) ValidateJoin(workflowIdx, processIdx int) error {
+	if process.Join == nil {
+		return nil
+	}
+
+	location := fmt.Sprintf("krt.workflows[%d].processes[%d].join", workflowIdx, processIdx)
+
+	if len(process.Subscriptions) < 2 {
+		return errors.InvalidJoinError("a join needs at least two subscriptions", location)
+	}
+
+	if !process.Join.Mode.IsValid() {
+		return errors.InvalidJoinModeError(location + ".mode")
+	}
+
+	if process.Join.Mode != JoinModeAllOf {
+		return nil
+	}
+
+	var totalError error
+
+	if process.Join.CorrelationKey == "" {
+		totalError = errors.Join(totalError, errors.MissingRequiredFieldError(location+".correlationKey"))
+	}
+
+	if process.Join.Timeout == "" {
+		totalError = errors.Join(totalError, errors.MissingRequiredFieldError(location+".timeout"))
+	} else {
+		totalError = errors.Join(totalError, validateDuration(process.Join.Timeout, location+".timeout"))
+	}
+
+	return totalError
 }
 
 func (process *Process) ValidateNodeSelectors(workflowIdx, processIdx int) error {
@@ -141,27 +298,39 @@ func (process *Process) ValidateNodeSelectors(workflowIdx, processIdx int) error
 	return errs
 }
 
+func (process *Process) ValidateMetadata(workflowIdx, processIdx int) error {
+	return validateMetadata(
+		process.Labels,
+		process.Annotations,
+		fmt.Sprintf("krt.workflows[%d].processes[%d]", workflowIdx, processIdx),
+	)
+}
+
 // validateSubscritpionRelationships checks if subscriptions for all processes are valid
 // inside a workflow context.
 //
 // All requirements for subscritpions to be valid can be found in the readme.
-func validateSubscritpionRelationships(processes []Process, workflowIdx int) error {
+//
+// Subscriptions to processes in other workflows are checked in the whole KRT context instead.
+func validateSubscritpionRelationships(processes []Process, workflowName string, workflowIdx int) error {
 	var totalError error
 
-	processTypesByNames, err := countProcessesSubscriptions(processes, workflowIdx)
+	processesByNames, err := countProcessesSubscriptions(processes, workflowName, workflowIdx)
 	totalError = errors.Join(totalError, err)
 
-	totalError = errors.Join(totalError, checkSubscriptions(processes, workflowIdx, processTypesByNames))
+	totalError = errors.Join(totalError, checkSubscriptions(processes, workflowName, workflowIdx, processesByNames))
+	totalError = errors.Join(totalError, checkJoins(processes, workflowName, workflowIdx, processesByNames))
 
 	return totalError
 }
 
-// countProcessesSubscriptions, will load processes types by their names
+// countProcessesSubscriptions, will load processes by their names
 // also, checks if there are enough processes, a duplicated process name or duplicated subscriptions.
-func countProcessesSubscriptions(processes []Process, workflowIdx int) (map[string]ProcessType, error) {
+// Subscriptions qualified with their own workflow are duplicates of the unqualified ones.
+func countProcessesSubscriptions(processes []Process, workflowName string, workflowIdx int) (map[string]*Process, error) {
 	var (
-		totalError          error
-		processTypesByNames = make(map[string]ProcessType)
+		totalError       error
+		processesByNames = make(map[string]*Process)
 	)
 
 	processCountByType := map[ProcessType]int{
@@ -173,7 +342,8 @@ func countProcessesSubscriptions(processes []Process, workflowIdx int) (map[stri
 	for processIdx, process := range processes {
 		var subscriptionAlreadyExists = make(map[string]bool)
 		for _, subscription := range process.Subscriptions {
-			if _, ok := subscriptionAlreadyExists[subscription]; ok {
+			reference := parseSubscription(subscription).local(workflowName).String()
+			if _, ok := subscriptionAlreadyExists[reference]; ok {
 				totalError = errors.Join(
 					totalError,
 					errors.DuplicatedProcessSubscriptionError(
@@ -181,7 +351,7 @@ func countProcessesSubscriptions(processes []Process, workflowIdx int) (map[stri
 					),
 				)
 			} else {
-				subscriptionAlreadyExists[subscription] = true
+				subscriptionAlreadyExists[reference] = true
 			}
 		}
 
@@ -189,7 +359,7 @@ func countProcessesSubscriptions(processes []Process, workflowIdx int) (map[stri
 			processCountByType[process.Type]++
 		}
 
-		if _, ok := processTypesByNames[process.Name]; ok {
+		if _, ok := processesByNames[process.Name]; ok {
 			totalError = errors.Join(
 				totalError,
 				errors.DuplicatedProcessNameError(
@@ -197,13 +367,13 @@ func countProcessesSubscriptions(processes []Process, workflowIdx int) (map[stri
 				),
 			)
 		} else {
-			processTypesByNames[process.Name] = process.Type
+			processesByNames[process.Name] = &processes[processIdx]
 		}
 	}
 
 	totalError = errors.Join(totalError, checkProcessCount(processCountByType, workflowIdx))
 
-	return processTypesByNames, totalError
+	return processesByNames, totalError
 }
 
 func checkProcessCount(processesCount map[ProcessType]int, workflowIdx int) error {
@@ -216,22 +386,25 @@ func checkProcessCount(processesCount map[ProcessType]int, workflowIdx int) erro
 	return nil
 }
 
-func checkSubscriptions(processes []Process, workflowIdx int, processTypesByNames map[string]ProcessType) error {
+func checkSubscriptions(processes []Process, workflowName string, workflowIdx int, processesByNames map[string]*Process) error {
 	var totalError error
 
 	for processIdx, process := range processes {
 		for _, subscription := range process.Subscriptions {
-			cleanSubscription := strings.Split(subscription, ".")[0]
+			reference := parseSubscription(subscription)
+			if reference.isCrossWorkflow(workflowName) {
+				continue
+			}
 
-			if process.Name == cleanSubscription {
-				totalError = errors.Join(totalError, errors.CannotSubscribeToItselfError(
-					fmt.Sprintf(subscriptionLocation, workflowIdx, processIdx, subscription),
-				))
+			location := fmt.Sprintf(subscriptionLocation, workflowIdx, processIdx, subscription)
+
+			if process.Name == reference.process {
+				totalError = errors.Join(totalError, errors.CannotSubscribeToItselfError(location))
 
 				continue
 			}
 
-			subscribedProcessType, processExists := processTypesByNames[cleanSubscription]
+			subscribedProcess, processExists := processesByNames[reference.process]
 			if !processExists {
 				totalError = errors.Join(totalError, errors.CannotSubscribeToNonExistentProcessError(
 					subscription,
@@ -241,25 +414,220 @@ func checkSubscriptions(processes []Process, workflowIdx int, processTypesByName
 				continue
 			}
 
-			if !isValidSubscription(process.Type, subscribedProcessType) {
+			if !isValidSubscription(&process, subscribedProcess) {
 				totalError = errors.Join(totalError, errors.InvalidProcessSubscriptionError(
-					string(process.Type),
-					string(subscribedProcessType),
-					fmt.Sprintf(subscriptionLocation, workflowIdx, processIdx, subscription),
+					process.kind(),
+					subscribedProcess.kind(),
+					location,
 				))
 			}
+
+			totalError = errors.Join(
+				totalError,
+				checkSubscriptionSubtopic(reference, subscribedProcess, location),
+				checkDeadLetter(
+					&process, subscription, subscribedProcess, processesByNames,
+					fmt.Sprintf(subscriptionSettingsLocation, workflowIdx, processIdx, subscription),
+				),
+			)
+		}
+	}
+
+	return totalError
+}
+
+// checkDeadLetter checks the dead-letter target of a subscription, if any, is a process of the workflow
+// that could subscribe to the subscribed process, as it will receive the messages that could not be delivered.
+func checkDeadLetter(
+	process *Process,
+	subscription string,
+	subscribedProcess *Process,
+	processesByNames map[string]*Process,
+	settingsLocation string,
+) error {
+	deadLetter := process.SubscriptionSettings[subscription].DeadLetter
+	if deadLetter == "" {
+		return nil
+	}
+
+	location := settingsLocation + ".deadLetter"
+
+	deadLetterProcess, ok := processesByNames[deadLetter]
+	if !ok {
+		return errors.DeadLetterNonExistentProcessError(deadLetter, location)
+	}
+
+	if deadLetterProcess.Name == process.Name {
+		return errors.InvalidDeadLetterError("it cannot be the subscribing process", location)
+	}
+
+	if deadLetterProcess.IsJob() {
+		return errors.InvalidDeadLetterError("it cannot be a job process", location)
+	}
+
+	if !isValidSubscription(deadLetterProcess, subscribedProcess) {
+		return errors.InvalidDeadLetterError(
+			fmt.Sprintf("a process of type %q cannot receive messages from %q processes", deadLetterProcess.kind(), subscribedProcess.kind()),
+			location,
+		)
+	}
+
+	return nil
+}
+
+// checkSubscriptionSubtopic checks the subtopic of a subscription, if any, is declared by the subscribed process.
+// Processes declaring no subtopics are not checked, as subtopics did not need to be declared before.
+func checkSubscriptionSubtopic(reference subscriptionReference, subscribedProcess *Process, location string) error {
+	if reference.subtopic == "" || len(subscribedProcess.Subtopics) == 0 {
+		return nil
+	}
+
+	for _, subtopic := range subscribedProcess.Subtopics {
+		if subtopic == reference.subtopic {
+			return nil
+		}
+	}
+
+	return errors.CannotSubscribeToNonExistentSubtopicError(
+		reference.subtopic,
+		subscribedProcess.Name,
+		closestMatch(reference.subtopic, subscribedProcess.Subtopics),
+		location,
+	)
+}
+
+// checkJoins checks every "all-of" join can be completed, that is, a single message entering
+// the workflow through a trigger can produce a message in every subscription of the join.
+func checkJoins(processes []Process, workflowName string, workflowIdx int, processesByNames map[string]*Process) error {
+	var totalError error
+
+	for processIdx, process := range processes {
+		if process.Join == nil || process.Join.Mode != JoinModeAllOf {
+			continue
+		}
+
+		location := fmt.Sprintf("krt.workflows[%d].processes[%d].join", workflowIdx, processIdx)
+		totalError = errors.Join(totalError, checkJoinInputs(&process, workflowName, processesByNames, location))
+	}
+
+	return totalError
+}
+
+func checkJoinInputs(process *Process, workflowName string, processesByNames map[string]*Process, location string) error {
+	var (
+		totalError     error
+		commonTriggers map[string]bool
+	)
+
+	for _, subscription := range process.Subscriptions {
+		reference := parseSubscription(subscription)
+
+		if reference.isCrossWorkflow(workflowName) {
+			totalError = errors.Join(totalError, errors.InvalidJoinError(
+				fmt.Sprintf("input %q comes from another workflow and cannot be correlated", subscription),
+				location,
+			))
+
+			continue
+		}
+
+		if _, ok := processesByNames[reference.process]; !ok {
+			continue // already reported as a subscription to a non existent process
+		}
+
+		triggers, dependsOnJoin := upstreamTriggers(reference.process, process.Name, workflowName, processesByNames)
+
+		switch {
+		case dependsOnJoin:
+			totalError = errors.Join(totalError, errors.InvalidJoinError(
+				fmt.Sprintf("input %q depends on the output of the joining process", subscription),
+				location,
+			))
+		case len(triggers) == 0:
+			totalError = errors.Join(totalError, errors.InvalidJoinError(
+				fmt.Sprintf("input %q cannot be reached from any trigger", subscription),
+				location,
+			))
+		case commonTriggers == nil:
+			commonTriggers = triggers
+		default:
+			for trigger := range commonTriggers {
+				if !triggers[trigger] {
+					delete(commonTriggers, trigger)
+				}
+			}
 		}
 	}
 
+	if totalError == nil && len(commonTriggers) == 0 {
+		return errors.InvalidJoinError("inputs are not produced from a common trigger", location)
+	}
+
 	return totalError
 }
 
-func isValidSubscription(processType, subscriptionProcessType ProcessType) bool {
-	switch processType {
+// upstreamTriggers walks the subscriptions of a process upwards, returning the triggers whose
+// messages can reach it and whether it depends on the messages of the given joining process.
+//
+// The subscriptions of triggers are not followed, as they only carry the workflow responses.
+func upstreamTriggers(processName, joiningProcess, workflowName string, processesByNames map[string]*Process) (map[string]bool, bool) {
+	var (
+		triggers      = make(map[string]bool)
+		visited       = make(map[string]bool)
+		pending       = []string{processName}
+		dependsOnJoin bool
+	)
+
+	for len(pending) > 0 {
+		current := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+
+		if visited[current] {
+			continue
+		}
+
+		visited[current] = true
+
+		if current == joiningProcess {
+			dependsOnJoin = true
+			continue
+		}
+
+		upstream, ok := processesByNames[current]
+		if !ok {
+			continue
+		}
+
+		if upstream.Type == ProcessTypeTrigger {
+			triggers[current] = true
+			continue
+		}
+
+		for _, subscription := range upstream.Subscriptions {
+			reference := parseSubscription(subscription).local(workflowName)
+			if reference.workflow == "" {
+				pending = append(pending, reference.process)
+			}
+		}
+	}
+
+	return triggers, dependsOnJoin
+}
+
+// isValidSubscription returns whether a process can receive messages from the subscribed process.
+//
+// Every message received starts a new run of a job, so jobs can only subscribe to triggers and other
+// jobs, never to the stream of messages of a service task.
+func isValidSubscription(process, subscribedProcess *Process) bool {
+	if process.IsJob() && subscribedProcess.Type == ProcessTypeTask && !subscribedProcess.IsJob() {
+		return false
+	}
+
+	switch process.Type {
 	case ProcessTypeTrigger:
-		return subscriptionProcessType == ProcessTypeExit
+		return subscribedProcess.Type == ProcessTypeExit
 	case ProcessTypeTask, ProcessTypeExit:
-		return subscriptionProcessType != ProcessTypeExit
+		return subscribedProcess.Type != ProcessTypeExit
 	default:
 		return false
 	}
@@ -270,36 +638,176 @@ func (process *Process) ValidateNetworking(workflowIdx, processIdx int) error {
 		return nil
 	}
 
+	location := fmt.Sprintf("krt.workflows[%d].processes[%d].networking", workflowIdx, processIdx)
+
+	if len(process.Networking.Ports) == 0 {
+		return validateNetworkingPort(process.Networking.GetPorts()[0], location)
+	}
+
 	var totalError error
-	if process.Networking.TargetPort == 0 {
-		totalError = errors.Join(
-			totalError,
-			errors.MissingRequiredFieldError(
-				fmt.Sprintf("krt.workflows[%d].processes[%d].networking.targetPort", workflowIdx, processIdx),
-			),
-		)
+
+	singlePortFields := []struct {
+		name  string
+		isSet bool
+	}{
+		{"targetPort", process.Networking.TargetPort != 0},
+		{"destinationPort", process.Networking.DestinationPort != 0},
+		{"protocol", process.Networking.Protocol != ""},
 	}
 
-	if process.Networking.DestinationPort == 0 {
+	for _, field := range singlePortFields {
+		if field.isSet {
+			totalError = errors.Join(totalError, errors.ConflictingNetworkingPortsError(location+"."+field.name))
+		}
+	}
+
+	portNames := make(map[string]bool)
+	targetPorts := make(map[int]bool)
+
+	for portIdx, port := range process.Networking.Ports {
+		portLocation := fmt.Sprintf("%s.ports[%d]", location, portIdx)
+
 		totalError = errors.Join(
 			totalError,
-			errors.MissingRequiredFieldError(
-				fmt.Sprintf("krt.workflows[%d].processes[%d].networking.destinationPort", workflowIdx, processIdx),
-			),
+			validateName(port.Name, portLocation+".name"),
+			validateNetworkingPort(port, portLocation),
 		)
+
+		if portNames[port.Name] {
+			totalError = errors.Join(totalError, errors.DuplicatedPortNameError(portLocation+".name"))
+		}
+
+		if port.TargetPort != 0 && targetPorts[port.TargetPort] {
+			totalError = errors.Join(totalError, errors.DuplicatedTargetPortError(portLocation+".targetPort"))
+		}
+
+		portNames[port.Name] = true
+		targetPorts[port.TargetPort] = true
 	}
 
-	if !process.Networking.Protocol.IsValid() {
-		totalError = errors.Join(
-			totalError, errors.InvalidNetworkingProtocolError(
-				fmt.Sprintf("krt.workflows[%d].processes[%d].networking.protocol", workflowIdx, processIdx),
-			),
-		)
+	return totalError
+}
+
+func validateNetworkingPort(port NetworkingPort, portLocation string) error {
+	totalError := errors.Join(
+		validatePortNumber(port.TargetPort, portLocation+".targetPort"),
+		validatePortNumber(port.DestinationPort, portLocation+".destinationPort"),
+	)
+
+	if !port.Protocol.IsValid() {
+		totalError = errors.Join(totalError, errors.InvalidNetworkingProtocolError(portLocation+".protocol"))
+	}
+
+	return totalError
+}
+
+func validatePortNumber(port int, portLocation string) error {
+	if port == 0 {
+		return errors.MissingRequiredFieldError(portLocation)
+	}
+
+	if port < MinPort || port > MaxPort {
+		return errors.InvalidPortError(portLocation)
+	}
+
+	return nil
+}
+
+// ValidateUnprivilegedPorts checks no process exposes a port lower than MinUnprivilegedPort. It is only
+// part of Validate with WithUnprivilegedPorts, for clusters that do not allow privileged ports by policy.
+func (krt *Krt) ValidateUnprivilegedPorts() error {
+	var totalError error
+
+	for workflowIdx, workflow := range krt.Workflows {
+		for processIdx, process := range workflow.Processes {
+			if process.Networking == nil {
+				continue
+			}
+
+			location := fmt.Sprintf("krt.workflows[%d].processes[%d].networking", workflowIdx, processIdx)
+
+			for portIdx, port := range process.Networking.GetPorts() {
+				portLocation := location
+				if len(process.Networking.Ports) > 0 {
+					portLocation = fmt.Sprintf("%s.ports[%d]", location, portIdx)
+				}
+
+				for _, portNumber := range []struct {
+					field string
+					port  int
+				}{{"targetPort", port.TargetPort}, {"destinationPort", port.DestinationPort}} {
+					if portNumber.port >= MinPort && portNumber.port < MinUnprivilegedPort {
+						totalError = errors.Join(totalError, errors.PrivilegedPortError(portLocation+"."+portNumber.field))
+					}
+				}
+			}
+		}
 	}
 
 	return totalError
 }
 
+func (process *Process) ValidateIngress(workflowIdx, processIdx int) error {
+	if process.Ingress == nil {
+		return nil
+	}
+
+	location := fmt.Sprintf("krt.workflows[%d].processes[%d].ingress", workflowIdx, processIdx)
+
+	if process.Type != ProcessTypeTrigger {
+		return errors.IngressNotAllowedError(string(process.Type), location)
+	}
+
+	if process.Networking == nil {
+		return errors.MissingRequiredFieldError(fmt.Sprintf("krt.workflows[%d].processes[%d].networking", workflowIdx, processIdx))
+	}
+
+	var totalError error
+
+	if err := kubeutil.ValidateHostname(process.Ingress.Host); err != nil {
+		totalError = errors.Join(totalError, fmt.Errorf("%s.host: invalid host %q: %w", location, process.Ingress.Host, err))
+	}
+
+	if !strings.HasPrefix(process.Ingress.PathPrefix, "/") {
+		totalError = errors.Join(totalError, errors.InvalidIngressPathPrefixError(location+".pathPrefix"))
+	}
+
+	if process.Ingress.TLSSecret != "" {
+		if err := kubeutil.ValidateDNSSubdomain(process.Ingress.TLSSecret); err != nil {
+			totalError = errors.Join(
+				totalError,
+				fmt.Errorf("%s.tlsSecret: invalid secret name %q: %w", location, process.Ingress.TLSSecret, err),
+			)
+		}
+	}
+
+	if !process.Ingress.Visibility.IsValid() {
+		totalError = errors.Join(totalError, errors.InvalidIngressVisibilityError(location+".visibility"))
+	}
+
+	return errors.Join(totalError, process.validateIngressPort(location))
+}
+
+func (process *Process) validateIngressPort(ingressLocation string) error {
+	ports := process.Networking.GetPorts()
+
+	if process.Ingress.Port == "" {
+		if len(ports) > 1 {
+			return errors.MissingRequiredFieldError(ingressLocation + ".port")
+		}
+
+		return nil
+	}
+
+	for _, port := range ports {
+		if port.Name == process.Ingress.Port {
+			return nil
+		}
+	}
+
+	return errors.IngressPortNotFoundError(process.Ingress.Port, ingressLocation+".port")
+}
+
 func (process *Process) ValidateResourceLimits(workflowIdx, processIdx int) error {
 	if process.ResourceLimits == nil {
 		return errors.MissingRequiredFieldError(
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/konstellation-io/krt/internal/kubeutil"
+	"github.com/konstellation-io/krt/pkg/errors"
 	"github.com/konstellation-io/krt/pkg/krt"
 	"github.com/stretchr/testify/assert"
 )
@@ -44,3 +45,201 @@ func TestProcess_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestProcess_ValidateNetworking(t *testing.T) {
+	testCases := []struct {
+		name          string
+		networking    *krt.ProcessNetworking
+		expectedError error
+	}{
+		{
+			"valid single port networking",
+			&krt.ProcessNetworking{TargetPort: 9000, DestinationPort: 9000, Protocol: krt.NetworkingProtocolGRPC},
+			nil,
+		},
+		{
+			"valid multiple ports networking",
+			&krt.ProcessNetworking{Ports: []krt.NetworkingPort{
+				{Name: "grpc", TargetPort: 9000, DestinationPort: 9000, Protocol: krt.NetworkingProtocolGRPC},
+				{Name: "ws", TargetPort: 9001, DestinationPort: 9001, Protocol: krt.NetworkingProtocolWebSocket},
+				{Name: "tcp", TargetPort: 9002, DestinationPort: 9002, Protocol: krt.NetworkingProtocolTCP},
+			}},
+			nil,
+		},
+		{
+			"port out of range",
+			&krt.ProcessNetworking{TargetPort: 70000, DestinationPort: 9000, Protocol: krt.NetworkingProtocolHTTP},
+			errors.ErrInvalidPort,
+		},
+		{
+			"privileged port",
+			&krt.ProcessNetworking{TargetPort: 9000, DestinationPort: 80, Protocol: krt.NetworkingProtocolHTTP},
+			nil,
+		},
+		{
+			"single port and ports list declared at the same time",
+			&krt.ProcessNetworking{TargetPort: 9000, Ports: []krt.NetworkingPort{
+				{Name: "http", TargetPort: 9000, DestinationPort: 9000, Protocol: krt.NetworkingProtocolHTTP},
+			}},
+			errors.ErrConflictingNetworkingPorts,
+		},
+		{
+			"single port protocol and ports list declared at the same time",
+			&krt.ProcessNetworking{Protocol: krt.NetworkingProtocolGRPC, Ports: []krt.NetworkingPort{
+				{Name: "http", TargetPort: 9000, DestinationPort: 9000, Protocol: krt.NetworkingProtocolHTTP},
+			}},
+			errors.ErrConflictingNetworkingPorts,
+		},
+		{
+			"duplicated port names",
+			&krt.ProcessNetworking{Ports: []krt.NetworkingPort{
+				{Name: "http", TargetPort: 9000, DestinationPort: 9000, Protocol: krt.NetworkingProtocolHTTP},
+				{Name: "http", TargetPort: 9001, DestinationPort: 9001, Protocol: krt.NetworkingProtocolHTTP},
+			}},
+			errors.ErrDuplicatedPortName,
+		},
+		{
+			"duplicated target ports",
+			&krt.ProcessNetworking{Ports: []krt.NetworkingPort{
+				{Name: "http", TargetPort: 9000, DestinationPort: 9000, Protocol: krt.NetworkingProtocolHTTP},
+				{Name: "grpc", TargetPort: 9000, DestinationPort: 9001, Protocol: krt.NetworkingProtocolGRPC},
+			}},
+			errors.ErrDuplicatedTargetPort,
+		},
+		{
+			"invalid port name",
+			&krt.ProcessNetworking{Ports: []krt.NetworkingPort{
+				{Name: "Invalid Name", TargetPort: 9000, DestinationPort: 9000, Protocol: krt.NetworkingProtocolHTTP},
+			}},
+			errors.ErrInvalidFieldName,
+		},
+		{
+			"unregistered protocol",
+			&krt.ProcessNetworking{Ports: []krt.NetworkingPort{
+				{Name: "udp", TargetPort: 9000, DestinationPort: 9000, Protocol: "UDP"},
+			}},
+			errors.ErrInvalidNetworkingProtocol,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			process := NewProcessBuilder().WithNetworking(tc.networking).Build()
+			assert.ErrorIs(t, process.ValidateNetworking(0, 0), tc.expectedError)
+		})
+	}
+}
+
+func TestRegisterNetworkingProtocol(t *testing.T) {
+	protocol := krt.NetworkingProtocol("AMQP")
+	assert.False(t, protocol.IsValid())
+
+	krt.RegisterNetworkingProtocol(protocol)
+	t.Cleanup(func() { krt.UnregisterNetworkingProtocol(protocol) })
+
+	assert.True(t, protocol.IsValid())
+	assert.Contains(t, krt.NetworkingProtocols(), protocol)
+}
+
+func TestKrt_Validate_UnprivilegedPorts(t *testing.T) {
+	krtYaml := NewKrtBuilder().
+		WithProcessNetworking(&krt.ProcessNetworking{TargetPort: 8080, DestinationPort: 80, Protocol: krt.NetworkingProtocolHTTP}, 0).
+		WithProcessNetworking(&krt.ProcessNetworking{Ports: []krt.NetworkingPort{
+			{Name: "http", TargetPort: 443, DestinationPort: 9000, Protocol: krt.NetworkingProtocolHTTP},
+		}}, 1).
+		Build()
+
+	assert.NoError(t, krtYaml.Validate())
+
+	err := krtYaml.Validate(krt.WithUnprivilegedPorts())
+	assert.ErrorIs(t, err, errors.ErrPrivilegedPort)
+	assert.Equal(t, errors.Join(
+		errors.PrivilegedPortError("krt.workflows[0].processes[0].networking.destinationPort"),
+		errors.PrivilegedPortError("krt.workflows[0].processes[1].networking.ports[0].targetPort"),
+	).Error(), err.Error())
+}
+
+func TestProcess_ValidateIngress(t *testing.T) {
+	singlePort := &krt.ProcessNetworking{TargetPort: 9000, DestinationPort: 9000, Protocol: krt.NetworkingProtocolHTTP}
+	multiplePorts := &krt.ProcessNetworking{Ports: []krt.NetworkingPort{
+		{Name: "http", TargetPort: 9000, DestinationPort: 9000, Protocol: krt.NetworkingProtocolHTTP},
+		{Name: "grpc", TargetPort: 9001, DestinationPort: 9001, Protocol: krt.NetworkingProtocolGRPC},
+	}}
+
+	validIngress := func() *krt.ProcessIngress {
+		return &krt.ProcessIngress{
+			Host:       "api.konstellation.io",
+			PathPrefix: "/classify",
+			TLSSecret:  "api-tls",
+			Visibility: krt.IngressVisibilityPublic,
+		}
+	}
+
+	testCases := []struct {
+		name          string
+		process       *krt.Process
+		expectedError error
+	}{
+		{
+			"valid ingress",
+			NewProcessBuilder().WithType(krt.ProcessTypeTrigger).WithNetworking(singlePort).WithIngress(validIngress()).Build(),
+			nil,
+		},
+		{
+			"ingress in a task process",
+			NewProcessBuilder().WithNetworking(singlePort).WithIngress(validIngress()).Build(),
+			errors.ErrIngressNotAllowed,
+		},
+		{
+			"ingress without networking",
+			NewProcessBuilder().WithType(krt.ProcessTypeTrigger).WithIngress(validIngress()).Build(),
+			errors.ErrMissingRequiredField,
+		},
+		{
+			"ingress with invalid host",
+			NewProcessBuilder().WithType(krt.ProcessTypeTrigger).WithNetworking(singlePort).WithIngress(
+				&krt.ProcessIngress{Host: "invalid host", PathPrefix: "/", Visibility: krt.IngressVisibilityInternal},
+			).Build(),
+			kubeutil.ErrInvalidHostname,
+		},
+		{
+			"ingress with invalid path prefix",
+			NewProcessBuilder().WithType(krt.ProcessTypeTrigger).WithNetworking(singlePort).WithIngress(
+				&krt.ProcessIngress{Host: "konstellation.io", PathPrefix: "classify", Visibility: krt.IngressVisibilityInternal},
+			).Build(),
+			errors.ErrInvalidIngressPathPrefix,
+		},
+		{
+			"ingress with invalid TLS secret name",
+			NewProcessBuilder().WithType(krt.ProcessTypeTrigger).WithNetworking(singlePort).WithIngress(
+				&krt.ProcessIngress{Host: "konstellation.io", PathPrefix: "/", TLSSecret: "Invalid", Visibility: krt.IngressVisibilityPublic},
+			).Build(),
+			kubeutil.ErrInvalidDNSName,
+		},
+		{
+			"ingress with invalid visibility",
+			NewProcessBuilder().WithType(krt.ProcessTypeTrigger).WithNetworking(singlePort).WithIngress(
+				&krt.ProcessIngress{Host: "konstellation.io", PathPrefix: "/", Visibility: "private"},
+			).Build(),
+			errors.ErrInvalidIngressVisibility,
+		},
+		{
+			"ingress without port when several ports are declared",
+			NewProcessBuilder().WithType(krt.ProcessTypeTrigger).WithNetworking(multiplePorts).WithIngress(validIngress()).Build(),
+			errors.ErrMissingRequiredField,
+		},
+		{
+			"ingress with non existent port",
+			NewProcessBuilder().WithType(krt.ProcessTypeTrigger).WithNetworking(multiplePorts).WithIngress(
+				&krt.ProcessIngress{Host: "konstellation.io", PathPrefix: "/", Port: "ws", Visibility: krt.IngressVisibilityPublic},
+			).Build(),
+			errors.ErrIngressPortNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.ErrorIs(t, tc.process.ValidateIngress(0, 0), tc.expectedError)
+		})
+	}
+}
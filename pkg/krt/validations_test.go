@@ -7,6 +7,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/konstellation-io/krt/internal/kubeutil"
 	"github.com/konstellation-io/krt/pkg/errors"
 	"github.com/konstellation-io/krt/pkg/krt"
 )
@@ -214,6 +215,13 @@ func TestKrtValidator(t *testing.T) {
 	}
 
 	invalidNameTests := []test{
+		{
+			name:        "fails if api version is not supported",
+			krtYaml:     NewKrtBuilder().WithAPIVersion(krt.APIVersionV1Alpha1).Build(),
+			wantError:   true,
+			errorType:   errors.ErrUnsupportedAPIVersion,
+			errorString: errors.UnsupportedAPIVersionError("krt/v1alpha1", "krt.apiVersion").Error(),
+		},
 		{
 			name:        "fails if version tag has an invalid format",
 			krtYaml:     NewKrtBuilder().WithVersion(invalidVersion).Build(),
@@ -508,6 +516,17 @@ func TestKrtValidator(t *testing.T) {
 			errorType:   errors.ErrDuplicatedProcessSubscription,
 			errorString: errors.DuplicatedProcessSubscriptionError("krt.workflows[0].processes[0].subscriptions.test-exit").Error(),
 		},
+		{
+			name: "fails if krt has a subscription duplicated with the qualified name of its workflow",
+			krtYaml: NewKrtBuilder().
+				WithProcessSubscriptions([]string{"test-trigger", "test-workflow/test-trigger"}, 1).
+				Build(),
+			wantError: true,
+			errorType: errors.ErrDuplicatedProcessSubscription,
+			errorString: errors.DuplicatedProcessSubscriptionError(
+				"krt.workflows[0].processes[1].subscriptions.test-workflow/test-trigger",
+			).Error(),
+		},
 		{
 			name: "fails if krt has invalid process subscriptions",
 			krtYaml: NewKrtBuilder().WithProcesses([]krt.Process{
@@ -566,6 +585,728 @@ func TestKrtValidator(t *testing.T) {
 			errorType:   errors.ErrCannotSubscribeToNonExistentProcess,
 			errorString: errors.CannotSubscribeToNonExistentProcessError("non-existent", "krt.workflows[0].processes[0]").Error(),
 		},
+		{
+			name: "does not fail if krt has a process subscribing to a declared subtopic",
+			krtYaml: NewKrtBuilder().
+				WithProcessSubtopics([]string{"repairs"}, 0).
+				WithProcessSubscriptions([]string{"test-trigger.repairs"}, 1).
+				Build(),
+			wantError: false,
+		},
+		{
+			name: "fails if krt has a process subscribing to a non declared subtopic",
+			krtYaml: NewKrtBuilder().
+				WithProcessSubtopics([]string{"repairs", "complaints"}, 0).
+				WithProcessSubscriptions([]string{"test-trigger.repair", "test-trigger.complaints"}, 1).
+				Build(),
+			wantError: true,
+			errorType: errors.ErrCannotSubscribeToNonExistentSubtopic,
+			errorString: errors.CannotSubscribeToNonExistentSubtopicError(
+				"repair", "test-trigger", "repairs", "krt.workflows[0].processes[1].subscriptions.test-trigger.repair",
+			).Error(),
+		},
+		{
+			name:      "does not fail if krt has a process subscribing to a subtopic of a process without subtopics",
+			krtYaml:   NewKrtBuilder().WithProcessSubscriptions([]string{"test-trigger.repairs"}, 1).Build(),
+			wantError: false,
+		},
+		{
+			name: "does not fail if krt has a subtopic nobody subscribes to",
+			krtYaml: NewKrtBuilder().
+				WithProcessSubtopics([]string{"repairs", "complaints"}, 0).
+				WithProcessSubscriptions([]string{"test-trigger.repairs"}, 1).
+				Build(),
+			wantError: false,
+		},
+		{
+			name: "fails if krt has duplicated subtopics",
+			krtYaml: NewKrtBuilder().
+				WithProcessSubtopics([]string{"repairs", "repairs"}, 0).
+				WithProcessSubscriptions([]string{"test-trigger.repairs"}, 1).
+				Build(),
+			wantError:   true,
+			errorType:   errors.ErrDuplicatedSubtopic,
+			errorString: errors.DuplicatedSubtopicError("krt.workflows[0].processes[0].subtopics[1]").Error(),
+		},
+	}
+
+	maxDeliver, invalidMaxAckPending := 5, 0
+
+	deliverySettingsTests := []test{
+		{
+			name: "does not fail if krt has valid subscription delivery settings",
+			krtYaml: NewKrtBuilder().
+				WithProcessSubscriptionSettings("test-trigger", krt.SubscriptionSettings{MaxDeliver: &maxDeliver, AckWait: "30s"}, 1).
+				Build(),
+			wantError: false,
+		},
+		{
+			name: "fails if krt has an invalid subscription ack wait",
+			krtYaml: NewKrtBuilder().
+				WithProcessSubscriptionSettings("test-trigger", krt.SubscriptionSettings{AckWait: "30"}, 1).
+				Build(),
+			wantError:   true,
+			errorType:   errors.ErrInvalidDuration,
+			errorString: errors.InvalidDurationError("krt.workflows[0].processes[1].subscriptionSettings.test-trigger.ackWait").Error(),
+		},
+		{
+			name: "fails if krt has a non positive subscription max ack pending",
+			krtYaml: NewKrtBuilder().
+				WithProcessSubscriptionSettings("test-trigger", krt.SubscriptionSettings{MaxAckPending: &invalidMaxAckPending}, 1).
+				Build(),
+			wantError: true,
+			errorType: errors.ErrValueMustBePositive,
+			errorString: errors.ValueMustBePositiveError(
+				"krt.workflows[0].processes[1].subscriptionSettings.test-trigger.maxAckPending",
+			).Error(),
+		},
+		{
+			name: "fails if krt has settings for a subscription the process does not declare",
+			krtYaml: NewKrtBuilder().
+				WithProcessSubscriptionSettings("test-exit", krt.SubscriptionSettings{AckWait: "30s"}, 1).
+				Build(),
+			wantError: true,
+			errorType: errors.ErrUnknownSubscriptionSettings,
+			errorString: errors.UnknownSubscriptionSettingsError(
+				"krt.workflows[0].processes[1].subscriptionSettings.test-exit",
+			).Error(),
+		},
+		{
+			name: "does not fail if krt has a valid dead-letter target",
+			krtYaml: NewKrtBuilder().
+				WithProcess(func() krt.Process {
+					fallback := NewKrtBuilder().Build().Workflows[0].Processes[1]
+					fallback.Name, fallback.Type, fallback.Subscriptions = "test-fallback", krt.ProcessTypeTask, []string{}
+
+					return fallback
+				}()).
+				WithProcessSubscriptionSettings("test-trigger", krt.SubscriptionSettings{DeadLetter: "test-fallback"}, 1).
+				Build(),
+			wantError: false,
+		},
+		{
+			name: "fails if krt has a non existent dead-letter target",
+			krtYaml: NewKrtBuilder().
+				WithProcessSubscriptionSettings("test-trigger", krt.SubscriptionSettings{DeadLetter: "non-existent"}, 1).
+				Build(),
+			wantError: true,
+			errorType: errors.ErrDeadLetterNonExistentProcess,
+			errorString: errors.DeadLetterNonExistentProcessError(
+				"non-existent", "krt.workflows[0].processes[1].subscriptionSettings.test-trigger.deadLetter",
+			).Error(),
+		},
+		{
+			name: "fails if krt has a dead-letter target that cannot subscribe to the subscribed process",
+			krtYaml: NewKrtBuilder().
+				WithProcessSubscriptionSettings("test-trigger", krt.SubscriptionSettings{DeadLetter: "test-trigger"}, 1).
+				Build(),
+			wantError: true,
+			errorType: errors.ErrInvalidDeadLetter,
+			errorString: errors.InvalidDeadLetterError(
+				`a process of type "trigger" cannot receive messages from "trigger" processes`,
+				"krt.workflows[0].processes[1].subscriptionSettings.test-trigger.deadLetter",
+			).Error(),
+		},
+	}
+
+	servingNetworking := &krt.ProcessNetworking{TargetPort: 9000, DestinationPort: 9000, Protocol: krt.NetworkingProtocolGRPC}
+	servingWorkflow := NewKrtBuilder().
+		WithWorkflowName("serving").
+		WithWorkflowType(krt.WorkflowTypeServing).
+		WithProcessNetworking(servingNetworking, 0).
+		Build().Workflows[0]
+
+	crossWorkflowSubscriptionTests := []test{
+		{
+			name: "does not fail if a feedback workflow subscribes to a serving workflow",
+			krtYaml: NewKrtBuilder().
+				WithWorkflowType(krt.WorkflowTypeFeedback).
+				WithProcessSubscriptions([]string{"test-exit", "serving/test-exit"}, 0).
+				WithWorkflow(servingWorkflow).
+				Build(),
+			wantError: false,
+		},
+		{
+			name: "fails if a serving workflow subscribes to another workflow",
+			krtYaml: NewKrtBuilder().
+				WithWorkflowType(krt.WorkflowTypeData).
+				WithWorkflow(krt.Workflow{
+					Name: "serving",
+					Type: krt.WorkflowTypeServing,
+					Processes: NewKrtBuilder().
+						WithProcessSubscriptions([]string{"test-exit", "test-workflow/test-exit"}, 0).
+						Build().Workflows[0].Processes,
+				}).
+				Build(),
+			wantError: true,
+			errorType: errors.ErrInvalidCrossWorkflowSubscription,
+			errorString: errors.InvalidCrossWorkflowSubscriptionError(
+				"serving", "data", "krt.workflows[1].processes[0].subscriptions.test-workflow/test-exit",
+			).Error(),
+		},
+		{
+			name: "fails if a process subscribes to a non existent workflow",
+			krtYaml: NewKrtBuilder().
+				WithProcessSubscriptions([]string{"test-exit", "non-existent/test-exit"}, 0).
+				Build(),
+			wantError: true,
+			errorType: errors.ErrCannotSubscribeToNonExistentWorkflow,
+			errorString: errors.CannotSubscribeToNonExistentWorkflowError(
+				"non-existent", "krt.workflows[0].processes[0].subscriptions.non-existent/test-exit",
+			).Error(),
+		},
+		{
+			name: "fails if a process subscribes to a non existent process in another workflow",
+			krtYaml: NewKrtBuilder().
+				WithWorkflowType(krt.WorkflowTypeFeedback).
+				WithProcessSubscriptions([]string{"test-exit", "serving/non-existent"}, 0).
+				WithWorkflow(servingWorkflow).
+				Build(),
+			wantError: true,
+			errorType: errors.ErrCannotSubscribeToNonExistentProcess,
+			errorString: errors.CannotSubscribeToNonExistentProcessError(
+				"serving/non-existent", "krt.workflows[0].processes[0].subscriptions.serving/non-existent",
+			).Error(),
+		},
+		{
+			name: "fails if a process subscribes to a process of an invalid type in another workflow",
+			krtYaml: NewKrtBuilder().
+				WithWorkflowType(krt.WorkflowTypeFeedback).
+				WithProcessSubscriptions([]string{"test-exit", "serving/test-trigger"}, 0).
+				WithWorkflow(servingWorkflow).
+				Build(),
+			wantError: true,
+			errorType: errors.ErrInvalidProcessSubscription,
+			errorString: errors.InvalidProcessSubscriptionError(
+				"trigger", "trigger", "krt.workflows[0].processes[0].subscriptions.serving/test-trigger",
+			).Error(),
+		},
+		{
+			name: "does not fail if a subtopic is only subscribed from another workflow",
+			krtYaml: NewKrtBuilder().
+				WithWorkflowType(krt.WorkflowTypeFeedback).
+				WithProcessSubscriptions([]string{"test-exit", "serving/test-exit.predictions"}, 0).
+				WithWorkflow(krt.Workflow{
+					Name: "serving",
+					Type: krt.WorkflowTypeServing,
+					Processes: NewKrtBuilder().
+						WithProcessNetworking(servingNetworking, 0).
+						WithProcessSubtopics([]string{"predictions"}, 1).
+						Build().Workflows[0].Processes,
+				}).
+				Build(),
+			wantError: false,
+		},
+	}
+
+	invalidNetworkingTests := []test{
+		{
+			name: "fails if two processes in a workflow use the same destination port",
+			krtYaml: NewKrtBuilder().
+				WithProcessNetworking(&krt.ProcessNetworking{TargetPort: 9000, DestinationPort: 9000, Protocol: "HTTP"}, 0).
+				WithProcessNetworking(&krt.ProcessNetworking{Ports: []krt.NetworkingPort{
+					{Name: "grpc", TargetPort: 9000, DestinationPort: 9000, Protocol: "GRPC"},
+				}}, 1).
+				Build(),
+			wantError: true,
+			errorType: errors.ErrDuplicatedDestinationPort,
+			errorString: errors.DuplicatedDestinationPortError(
+				"krt.workflows[0].processes[1].networking.ports[0].destinationPort",
+				"krt.workflows[0].processes[0].networking.destinationPort",
+			).Error(),
+		},
+	}
+
+	invalidIngressTests := []test{
+		{
+			name: "fails if two workflows expose the same ingress route",
+			krtYaml: NewKrtBuilder().
+				WithProcessNetworking(&krt.ProcessNetworking{TargetPort: 9000, DestinationPort: 9000, Protocol: "HTTP"}, 0).
+				WithProcessIngress(&krt.ProcessIngress{Host: "konstellation.io", PathPrefix: "/api/", Visibility: "public"}, 0).
+				WithWorkflow(krt.Workflow{
+					Name: "other-workflow",
+					Type: krt.WorkflowTypeServing,
+					Processes: NewKrtBuilder().
+						WithProcessNetworking(&krt.ProcessNetworking{TargetPort: 9000, DestinationPort: 9000, Protocol: "HTTP"}, 0).
+						WithProcessIngress(&krt.ProcessIngress{Host: "konstellation.io", PathPrefix: "/api", Visibility: "internal"}, 0).
+						Build().Workflows[0].Processes,
+				}).
+				Build(),
+			wantError: true,
+			errorType: errors.ErrDuplicatedIngressRoute,
+			errorString: errors.DuplicatedIngressRouteError(
+				"konstellation.io/api",
+				"krt.workflows[1].processes[0].ingress",
+				"krt.workflows[0].processes[0].ingress",
+			).Error(),
+		},
+	}
+
+	invalidConfigTests := []test{
+		{
+			name:      "does not fail if config keys are valid env var names",
+			krtYaml:   NewKrtBuilder().WithVersionConfig(map[string]string{"key_1": "value", "_KEY": "value"}).Build(),
+			wantError: false,
+		},
+		{
+			name:        "fails if product config key is not a valid env var name",
+			krtYaml:     NewKrtBuilder().WithVersionConfig(map[string]string{"my.key-1": "value"}).Build(),
+			wantError:   true,
+			errorType:   errors.ErrInvalidConfigKey,
+			errorString: errors.InvalidConfigKeyError("krt.config.my.key-1").Error(),
+		},
+		{
+			name:        "fails if workflow config key starts with a number",
+			krtYaml:     NewKrtBuilder().WithWorkflowConfig(map[string]string{"1abc": "value"}).Build(),
+			wantError:   true,
+			errorType:   errors.ErrInvalidConfigKey,
+			errorString: errors.InvalidConfigKeyError("krt.workflows[0].config.1abc").Error(),
+		},
+		{
+			name:        "fails if process config key uses a reserved prefix",
+			krtYaml:     NewKrtBuilder().WithProcessConfig(map[string]string{"kai_token": "value"}, 0).Build(),
+			wantError:   true,
+			errorType:   errors.ErrReservedConfigKey,
+			errorString: errors.ReservedConfigKeyError("krt.workflows[0].processes[0].config.kai_token").Error(),
+		},
+		{
+			name:        "fails if process secret is not a valid env var name",
+			krtYaml:     NewKrtBuilder().WithProcessSecrets([]string{"api-key"}, 0).Build(),
+			wantError:   true,
+			errorType:   errors.ErrInvalidConfigKey,
+			errorString: errors.InvalidConfigKeyError("krt.workflows[0].processes[0].secrets.api-key").Error(),
+		},
+		{
+			name:      "does not fail if a process overrides a product config key",
+			krtYaml:   NewKrtBuilder().WithVersionConfig(map[string]string{"KEY": "a"}).WithProcessConfig(map[string]string{"KEY": "b"}, 0).Build(),
+			wantError: false,
+		},
+		{
+			name:        "fails if config keys differ only in case across levels",
+			krtYaml:     NewKrtBuilder().WithVersionConfig(map[string]string{"KEY": "a"}).WithWorkflowConfig(map[string]string{"key": "b"}).Build(),
+			wantError:   true,
+			errorType:   errors.ErrConflictingConfigKey,
+			errorString: errors.ConflictingConfigKeyError("krt.workflows[0].config.key", "krt.config.KEY").Error(),
+		},
+		{
+			name:      "fails if config keys differ only in case in the same level",
+			krtYaml:   NewKrtBuilder().WithProcessConfig(map[string]string{"Key": "a", "key": "b"}, 1).Build(),
+			wantError: true,
+			errorType: errors.ErrConflictingConfigKey,
+			errorString: errors.ConflictingConfigKeyError(
+				"krt.workflows[0].processes[1].config.key", "krt.workflows[0].processes[1].config.Key",
+			).Error(),
+		},
+	}
+
+	taskProcess := func(name string, processes ...string) krt.Process {
+		task := NewKrtBuilder().Build().Workflows[0].Processes[1]
+		task.Name, task.Type, task.Subscriptions = name, krt.ProcessTypeTask, processes
+
+		return task
+	}
+
+	allOfJoin := func() *krt.ProcessJoin {
+		return &krt.ProcessJoin{Mode: krt.JoinModeAllOf, CorrelationKey: "request-id", Timeout: "30s"}
+	}
+
+	fanInKrt := func() *KrtBuilder {
+		return NewKrtBuilder().
+			WithProcess(taskProcess("branch-a", "test-trigger")).
+			WithProcess(taskProcess("branch-b", "test-trigger")).
+			WithProcessSubscriptions([]string{"branch-a", "branch-b"}, 1)
+	}
+
+	joinTests := []test{
+		{
+			name:      "does not fail if an all-of join inputs share a trigger",
+			krtYaml:   fanInKrt().WithProcessJoin(allOfJoin(), 1).Build(),
+			wantError: false,
+		},
+		{
+			name: "does not fail if an all-of join input is subscribed with the qualified name of its workflow",
+			krtYaml: NewKrtBuilder().
+				WithProcess(taskProcess("branch-a", "test-trigger")).
+				WithProcess(taskProcess("branch-b", "test-workflow/test-trigger")).
+				WithProcessSubscriptions([]string{"branch-a", "test-workflow/branch-b"}, 1).
+				WithProcessJoin(allOfJoin(), 1).
+				Build(),
+			wantError: false,
+		},
+		{
+			name:      "does not fail if an any-of join has no correlation key",
+			krtYaml:   fanInKrt().WithProcessJoin(&krt.ProcessJoin{Mode: krt.JoinModeAnyOf}, 1).Build(),
+			wantError: false,
+		},
+		{
+			name:      "fails if a join is declared with a single subscription",
+			krtYaml:   NewKrtBuilder().WithProcessJoin(&krt.ProcessJoin{Mode: krt.JoinModeAnyOf}, 1).Build(),
+			wantError: true,
+			errorType: errors.ErrInvalidJoin,
+			errorString: errors.InvalidJoinError(
+				"a join needs at least two subscriptions", "krt.workflows[0].processes[1].join",
+			).Error(),
+		},
+		{
+			name:        "fails if a join has an invalid mode",
+			krtYaml:     fanInKrt().WithProcessJoin(&krt.ProcessJoin{Mode: "first-of"}, 1).Build(),
+			wantError:   true,
+			errorType:   errors.ErrInvalidJoinMode,
+			errorString: errors.InvalidJoinModeError("krt.workflows[0].processes[1].join.mode").Error(),
+		},
+		{
+			name:        "fails if an all-of join has no correlation key",
+			krtYaml:     fanInKrt().WithProcessJoin(&krt.ProcessJoin{Mode: krt.JoinModeAllOf, Timeout: "30s"}, 1).Build(),
+			wantError:   true,
+			errorType:   errors.ErrMissingRequiredField,
+			errorString: errors.MissingRequiredFieldError("krt.workflows[0].processes[1].join.correlationKey").Error(),
+		},
+		{
+			name: "fails if an all-of join has an invalid timeout",
+			krtYaml: fanInKrt().WithProcessJoin(
+				&krt.ProcessJoin{Mode: krt.JoinModeAllOf, CorrelationKey: "request-id", Timeout: "soon"}, 1,
+			).Build(),
+			wantError:   true,
+			errorType:   errors.ErrInvalidDuration,
+			errorString: errors.InvalidDurationError("krt.workflows[0].processes[1].join.timeout").Error(),
+		},
+		{
+			name: "fails if an all-of join inputs are not produced from a common trigger",
+			krtYaml: fanInKrt().
+				WithProcess(func() krt.Process {
+					trigger := NewKrtBuilder().Build().Workflows[0].Processes[0]
+					trigger.Name = "other-trigger"
+
+					return trigger
+				}()).
+				WithProcessSubscriptions([]string{"other-trigger"}, 3).
+				WithProcessJoin(allOfJoin(), 1).
+				Build(),
+			wantError: true,
+			errorType: errors.ErrInvalidJoin,
+			errorString: errors.InvalidJoinError(
+				"inputs are not produced from a common trigger", "krt.workflows[0].processes[1].join",
+			).Error(),
+		},
+		{
+			name: "fails if an all-of join input depends on the joining process",
+			krtYaml: NewKrtBuilder().
+				WithProcess(taskProcess("branch-a", "test-trigger")).
+				WithProcess(taskProcess("joiner", "branch-a", "branch-b")).
+				WithProcess(taskProcess("branch-b", "joiner")).
+				WithProcessSubscriptions([]string{"joiner"}, 1).
+				WithProcessJoin(allOfJoin(), 3).
+				Build(),
+			wantError: true,
+			errorType: errors.ErrInvalidJoin,
+			errorString: errors.InvalidJoinError(
+				`input "branch-b" depends on the output of the joining process`, "krt.workflows[0].processes[3].join",
+			).Error(),
+		},
+		{
+			name: "fails if an all-of join has an input from another workflow",
+			krtYaml: NewKrtBuilder().
+				WithWorkflowType(krt.WorkflowTypeFeedback).
+				WithProcess(taskProcess("branch-a", "test-trigger")).
+				WithProcessSubscriptions([]string{"branch-a", "serving/test-trigger"}, 1).
+				WithProcessJoin(allOfJoin(), 1).
+				WithWorkflow(servingWorkflow).
+				Build(),
+			wantError: true,
+			errorType: errors.ErrInvalidJoin,
+			errorString: errors.InvalidJoinError(
+				`input "serving/test-trigger" comes from another workflow and cannot be correlated`,
+				"krt.workflows[0].processes[1].join",
+			).Error(),
+		},
+	}
+
+	maxAttempts, invalidMaxAttempts, invalidMultiplier := 3, 0, 0.5
+
+	executionTests := []test{
+		{
+			name: "does not fail if krt has valid timeouts and retry policies",
+			krtYaml: NewKrtBuilder().
+				WithWorkflowDefaults(&krt.WorkflowDefaults{Timeout: "1m", Retry: &krt.RetryPolicy{MaxAttempts: &maxAttempts}}).
+				WithProcessTimeout("2m", 0).
+				WithProcessRetry(&krt.RetryPolicy{
+					MaxAttempts: &maxAttempts,
+					Backoff:     &krt.RetryBackoff{Initial: "1s", Max: "10s"},
+				}, 1).
+				Build(),
+			wantError: false,
+		},
+		{
+			name:        "fails if a process has an invalid timeout",
+			krtYaml:     NewKrtBuilder().WithProcessTimeout("forever", 1).Build(),
+			wantError:   true,
+			errorType:   errors.ErrInvalidDuration,
+			errorString: errors.InvalidDurationError("krt.workflows[0].processes[1].timeout").Error(),
+		},
+		{
+			name:        "fails if a workflow has an invalid default timeout",
+			krtYaml:     NewKrtBuilder().WithWorkflowDefaults(&krt.WorkflowDefaults{Timeout: "-1s"}).Build(),
+			wantError:   true,
+			errorType:   errors.ErrInvalidDuration,
+			errorString: errors.InvalidDurationError("krt.workflows[0].defaults.timeout").Error(),
+		},
+		{
+			name:        "fails if a trigger process declares a retry policy",
+			krtYaml:     NewKrtBuilder().WithProcessRetry(&krt.RetryPolicy{MaxAttempts: &maxAttempts}, 0).Build(),
+			wantError:   true,
+			errorType:   errors.ErrRetryNotAllowed,
+			errorString: errors.RetryNotAllowedError("trigger", "krt.workflows[0].processes[0].retry").Error(),
+		},
+		{
+			name:        "fails if a retry policy has no max attempts",
+			krtYaml:     NewKrtBuilder().WithProcessRetry(&krt.RetryPolicy{}, 1).Build(),
+			wantError:   true,
+			errorType:   errors.ErrMissingRequiredField,
+			errorString: errors.MissingRequiredFieldError("krt.workflows[0].processes[1].retry.maxAttempts").Error(),
+		},
+		{
+			name: "fails if a workflow default retry policy has non positive max attempts",
+			krtYaml: NewKrtBuilder().
+				WithWorkflowDefaults(&krt.WorkflowDefaults{Retry: &krt.RetryPolicy{MaxAttempts: &invalidMaxAttempts}}).
+				Build(),
+			wantError:   true,
+			errorType:   errors.ErrValueMustBePositive,
+			errorString: errors.ValueMustBePositiveError("krt.workflows[0].defaults.retry.maxAttempts").Error(),
+		},
+		{
+			name: "fails if a retry backoff max wait is lower than the initial wait",
+			krtYaml: NewKrtBuilder().WithProcessRetry(&krt.RetryPolicy{
+				MaxAttempts: &maxAttempts,
+				Backoff:     &krt.RetryBackoff{Initial: "10s", Max: "1s"},
+			}, 1).Build(),
+			wantError: true,
+			errorType: errors.ErrInvalidBackoff,
+			errorString: errors.InvalidBackoffError(
+				"max wait cannot be lower than the initial wait", "krt.workflows[0].processes[1].retry.backoff.max",
+			).Error(),
+		},
+		{
+			name: "fails if a retry backoff multiplier is lower than one",
+			krtYaml: NewKrtBuilder().WithProcessRetry(&krt.RetryPolicy{
+				MaxAttempts: &maxAttempts,
+				Backoff:     &krt.RetryBackoff{Initial: "1s", Max: "10s", Multiplier: &invalidMultiplier},
+			}, 1).Build(),
+			wantError: true,
+			errorType: errors.ErrInvalidBackoff,
+			errorString: errors.InvalidBackoffError(
+				"multiplier cannot be lower than 1", "krt.workflows[0].processes[1].retry.backoff.multiplier",
+			).Error(),
+		},
+		{
+			name:      "fails if an exit process waits longer than the trigger",
+			krtYaml:   NewKrtBuilder().WithProcessTimeout("30s", 0).WithProcessTimeout("1m", 1).Build(),
+			wantError: true,
+			errorType: errors.ErrTimeoutExceedsTrigger,
+			errorString: errors.TimeoutExceedsTriggerError(
+				"test-trigger", "krt.workflows[0].processes[1].timeout", "krt.workflows[0].processes[0].timeout",
+			).Error(),
+		},
+		{
+			name: "fails if an exit process default timeout is longer than the trigger",
+			krtYaml: NewKrtBuilder().
+				WithWorkflowDefaults(&krt.WorkflowDefaults{Timeout: "5m"}).
+				WithProcessTimeout("30s", 0).
+				Build(),
+			wantError: true,
+			errorType: errors.ErrTimeoutExceedsTrigger,
+			errorString: errors.TimeoutExceedsTriggerError(
+				"test-trigger", "krt.workflows[0].defaults.timeout", "krt.workflows[0].processes[0].timeout",
+			).Error(),
+		},
+		{
+			name: "fails if an exit process waits longer than the trigger default timeout",
+			krtYaml: NewKrtBuilder().
+				WithWorkflowDefaults(&krt.WorkflowDefaults{Timeout: "30s"}).
+				WithProcessTimeout("5m", 1).
+				Build(),
+			wantError: true,
+			errorType: errors.ErrTimeoutExceedsTrigger,
+			errorString: errors.TimeoutExceedsTriggerError(
+				"test-trigger", "krt.workflows[0].processes[1].timeout", "krt.workflows[0].defaults.timeout",
+			).Error(),
+		},
+	}
+
+	completions, invalidBackoffLimit, replicas := 4, -1, 2
+
+	jobKrt := func() *KrtBuilder {
+		return NewKrtBuilder().
+			WithProcess(taskProcess("train", "test-trigger")).
+			WithProcessMode(krt.ProcessModeJob, &krt.ProcessJob{Completions: &completions}, 2).
+			WithProcessSubscriptions([]string{"train"}, 1)
+	}
+
+	jobTests := []test{
+		{
+			name:      "does not fail if a training workflow has a job subscribed to a trigger",
+			krtYaml:   jobKrt().Build(),
+			wantError: false,
+		},
+		{
+			name:        "fails if a process has an invalid mode",
+			krtYaml:     NewKrtBuilder().WithProcessMode("cronjob", nil, 1).Build(),
+			wantError:   true,
+			errorType:   errors.ErrInvalidProcessMode,
+			errorString: errors.InvalidProcessModeError("krt.workflows[0].processes[1].mode").Error(),
+		},
+		{
+			name:        "fails if a service process declares job settings",
+			krtYaml:     NewKrtBuilder().WithProcessMode(krt.ProcessModeService, &krt.ProcessJob{}, 1).Build(),
+			wantError:   true,
+			errorType:   errors.ErrUnexpectedJobConfig,
+			errorString: errors.UnexpectedJobConfigError("krt.workflows[0].processes[1].job").Error(),
+		},
+		{
+			name:        "fails if an exit process runs as a job",
+			krtYaml:     NewKrtBuilder().WithProcessMode(krt.ProcessModeJob, nil, 1).Build(),
+			wantError:   true,
+			errorType:   errors.ErrJobNotAllowed,
+			errorString: errors.JobNotAllowedError("exit", "krt.workflows[0].processes[1].mode").Error(),
+		},
+		{
+			name:      "fails if a serving workflow has a job",
+			krtYaml:   jobKrt().WithWorkflowType(krt.WorkflowTypeServing).Build(),
+			wantError: true,
+			errorType: errors.ErrJobNotAllowedInWorkflow,
+			errorString: errors.JobNotAllowedInWorkflowError(
+				"serving", "krt.workflows[0].processes[2].mode",
+			).Error(),
+		},
+		{
+			name:        "fails if a job has several replicas",
+			krtYaml:     jobKrt().WithProcessReplicas(&replicas, 2).Build(),
+			wantError:   true,
+			errorType:   errors.ErrJobReplicas,
+			errorString: errors.JobReplicasError("krt.workflows[0].processes[2].replicas").Error(),
+		},
+		{
+			name: "fails if a job has a negative backoff limit",
+			krtYaml: jobKrt().
+				WithProcessMode(krt.ProcessModeJob, &krt.ProcessJob{BackoffLimit: &invalidBackoffLimit}, 2).
+				Build(),
+			wantError:   true,
+			errorType:   errors.ErrValueMustNotBeNegative,
+			errorString: errors.ValueMustNotBeNegativeError("krt.workflows[0].processes[2].job.backoffLimit").Error(),
+		},
+		{
+			name: "fails if a job has several subscriptions",
+			krtYaml: jobKrt().
+				WithProcess(taskProcess("prepare", "test-trigger")).
+				WithProcessMode(krt.ProcessModeJob, nil, 3).
+				WithProcessSubscriptions([]string{"test-trigger", "prepare"}, 2).
+				Build(),
+			wantError:   true,
+			errorType:   errors.ErrInvalidJobSubscriptions,
+			errorString: errors.InvalidJobSubscriptionsError("krt.workflows[0].processes[2].subscriptions").Error(),
+		},
+		{
+			name: "fails if a job subscribes to a service task",
+			krtYaml: jobKrt().
+				WithProcess(taskProcess("prepare", "test-trigger")).
+				WithProcessSubscriptions([]string{"prepare"}, 2).
+				Build(),
+			wantError: true,
+			errorType: errors.ErrInvalidProcessSubscription,
+			errorString: errors.InvalidProcessSubscriptionError(
+				"job", "task", "krt.workflows[0].processes[2].subscriptions.prepare",
+			).Error(),
+		},
+		{
+			name: "fails if a dead-letter target is a job",
+			krtYaml: jobKrt().
+				WithProcessSubscriptions([]string{"train"}, 1).
+				WithProcessSubscriptionSettings("train", krt.SubscriptionSettings{DeadLetter: "train"}, 1).
+				Build(),
+			wantError: true,
+			errorType: errors.ErrInvalidDeadLetter,
+			errorString: errors.InvalidDeadLetterError(
+				"it cannot be a job process", "krt.workflows[0].processes[1].subscriptionSettings.train.deadLetter",
+			).Error(),
+		},
+	}
+
+	gpu := true
+
+	workflowTypeTests := []test{
+		{
+			name:      "does not fail if a training workflow declares GPUs",
+			krtYaml:   NewKrtBuilder().WithProcessGPU(&gpu, 1).Build(),
+			wantError: false,
+		},
+		{
+			name:      "fails if a feedback workflow declares GPUs",
+			krtYaml:   NewKrtBuilder().WithWorkflowType(krt.WorkflowTypeFeedback).WithProcessGPU(&gpu, 1).Build(),
+			wantError: true,
+			errorType: errors.ErrGPUNotAllowedInWorkflow,
+			errorString: errors.GPUNotAllowedInWorkflowError(
+				"feedback", "krt.workflows[0].processes[1].gpu",
+			).Error(),
+		},
+		{
+			name:        "fails if a serving workflow has no trigger with networking",
+			krtYaml:     NewKrtBuilder().WithWorkflowType(krt.WorkflowTypeServing).Build(),
+			wantError:   true,
+			errorType:   errors.ErrMissingNetworkedTrigger,
+			errorString: errors.MissingNetworkedTriggerError("serving", "krt.workflows[0].processes").Error(),
+		},
+		{
+			name: "does not fail if a serving workflow has a trigger with networking",
+			krtYaml: NewKrtBuilder().
+				WithWorkflowType(krt.WorkflowTypeServing).
+				WithProcessNetworking(servingNetworking, 0).
+				Build(),
+			wantError: false,
+		},
+		{
+			name:      "fails if a feedback workflow does not subscribe to another workflow",
+			krtYaml:   NewKrtBuilder().WithWorkflowType(krt.WorkflowTypeFeedback).Build(),
+			wantError: true,
+			errorType: errors.ErrMissingCrossWorkflowSubscription,
+			errorString: errors.MissingCrossWorkflowSubscriptionError(
+				"feedback", "krt.workflows[0].processes",
+			).Error(),
+		},
+	}
+
+	metadataTests := []test{
+		{
+			name: "does not fail if krt has valid labels and annotations",
+			krtYaml: NewKrtBuilder().
+				WithLabels(map[string]string{"konstellation.io/owner": "ml-team", "cost-center": ""}).
+				WithAnnotations(map[string]string{"description": "Any text, even with spaces"}).
+				WithWorkflowLabels(map[string]string{"team": "training"}).
+				WithProcessLabels(map[string]string{"tier": "backend"}, 0).
+				Build(),
+			wantError: false,
+		},
+		{
+			name:        "fails if krt has an invalid label key",
+			krtYaml:     NewKrtBuilder().WithLabels(map[string]string{"invalid key": "value"}).Build(),
+			wantError:   true,
+			errorType:   kubeutil.ErrInvalidKeyName,
+			errorString: `krt.labels: invalid key "invalid key"`,
+		},
+		{
+			name:        "fails if a workflow has an invalid label value",
+			krtYaml:     NewKrtBuilder().WithWorkflowLabels(map[string]string{"team": "invalid value"}).Build(),
+			wantError:   true,
+			errorType:   kubeutil.ErrInvalidValue,
+			errorString: `krt.workflows[0].labels.team: invalid value "invalid value"`,
+		},
+		{
+			name: "fails if a process has an invalid annotation key",
+			krtYaml: NewKrtBuilder().
+				WithProcessAnnotations(map[string]string{"invalid prefix/description": "value"}, 1).
+				Build(),
+			wantError:   true,
+			errorType:   kubeutil.ErrInvalidKeyPrefix,
+			errorString: `krt.workflows[0].processes[1].annotations: invalid key "invalid prefix/description"`,
+		},
 	}
 
 	allTests := make([]test, 0)
@@ -575,6 +1316,16 @@ func TestKrtValidator(t *testing.T) {
 	allTests = append(allTests, invalidTypeTests...)
 	allTests = append(allTests, invalidResourceRelationTests...)
 	allTests = append(allTests, invalidSubscriptionTests...)
+	allTests = append(allTests, deliverySettingsTests...)
+	allTests = append(allTests, crossWorkflowSubscriptionTests...)
+	allTests = append(allTests, joinTests...)
+	allTests = append(allTests, executionTests...)
+	allTests = append(allTests, jobTests...)
+	allTests = append(allTests, workflowTypeTests...)
+	allTests = append(allTests, metadataTests...)
+	allTests = append(allTests, invalidNetworkingTests...)
+	allTests = append(allTests, invalidIngressTests...)
+	allTests = append(allTests, invalidConfigTests...)
 
 	for _, tc := range allTests {
 		t.Run(tc.name, func(t *testing.T) {
@@ -588,3 +1339,19 @@ func TestKrtValidator(t *testing.T) {
 		})
 	}
 }
+
+func TestKrt_Validate_StrictSubtopics(t *testing.T) {
+	krtYaml := NewKrtBuilder().
+		WithProcessSubtopics([]string{"repairs", "complaints"}, 0).
+		WithProcessSubscriptions([]string{"test-trigger.repairs"}, 1).
+		Build()
+
+	unusedSubtopicError := errors.UnusedSubtopicError("krt.workflows[0].processes[0].subtopics[1]")
+
+	err := krtYaml.ValidateUnusedSubtopics()
+	assert.EqualError(t, err, unusedSubtopicError.Error())
+
+	err = krtYaml.Validate(krt.WithStrictSubtopics())
+	assert.ErrorIs(t, err, errors.ErrUnusedSubtopic)
+	assert.EqualError(t, err, unusedSubtopicError.Error())
+}
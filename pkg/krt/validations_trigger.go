@@ -0,0 +1,169 @@
+package krt
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/konstellation-io/krt/internal/cronutil"
+	"github.com/konstellation-io/krt/pkg/errors"
+)
+
+// triggerKindSchema describes the configuration block a trigger kind requires and how to validate it.
+type triggerKindSchema struct {
+	block    string
+	validate func(process *Process, processLocation string) error
+}
+
+func triggerKindSchemas() map[TriggerKind]triggerKindSchema {
+	return map[TriggerKind]triggerKindSchema{
+		TriggerKindGRPC:         {validate: networkedTriggerValidator(NetworkingProtocolGRPC)},
+		TriggerKindHTTP:         {validate: networkedTriggerValidator(NetworkingProtocolHTTP)},
+		TriggerKindCron:         {block: "cron", validate: validateCronTrigger},
+		TriggerKindObjectStore:  {block: "objectStore", validate: validateObjectStoreTrigger},
+		TriggerKindMessageQueue: {block: "messageQueue", validate: validateMessageQueueTrigger},
+	}
+}
+
+// declaredBlocks returns the kind specific configuration blocks present in the trigger.
+func (trigger *ProcessTrigger) declaredBlocks() []string {
+	blocks := make([]string, 0)
+
+	if trigger.Cron != nil {
+		blocks = append(blocks, "cron")
+	}
+
+	if trigger.ObjectStore != nil {
+		blocks = append(blocks, "objectStore")
+	}
+
+	if trigger.MessageQueue != nil {
+		blocks = append(blocks, "messageQueue")
+	}
+
+	return blocks
+}
+
+func (process *Process) ValidateTrigger(workflowIdx, processIdx int) error {
+	if process.Trigger == nil {
+		return nil
+	}
+
+	processLocation := fmt.Sprintf("krt.workflows[%d].processes[%d]", workflowIdx, processIdx)
+	location := processLocation + ".trigger"
+
+	if process.Type != ProcessTypeTrigger {
+		return errors.TriggerNotAllowedError(string(process.Type), location)
+	}
+
+	schema, ok := triggerKindSchemas()[process.Trigger.Kind]
+	if !ok {
+		return errors.InvalidTriggerKindError(location + ".kind")
+	}
+
+	var totalError error
+
+	blockDeclared := false
+
+	for _, block := range process.Trigger.declaredBlocks() {
+		if block == schema.block {
+			blockDeclared = true
+			continue
+		}
+
+		totalError = errors.Join(
+			totalError,
+			errors.UnexpectedTriggerConfigError(string(process.Trigger.Kind), fmt.Sprintf("%s.%s", location, block)),
+		)
+	}
+
+	if schema.block != "" && !blockDeclared {
+		return errors.Join(totalError, errors.MissingRequiredFieldError(fmt.Sprintf("%s.%s", location, schema.block)))
+	}
+
+	return errors.Join(totalError, schema.validate(process, processLocation))
+}
+
+func networkedTriggerValidator(protocol NetworkingProtocol) func(process *Process, processLocation string) error {
+	return func(process *Process, processLocation string) error {
+		if process.Networking == nil {
+			return errors.MissingRequiredFieldError(processLocation + ".networking")
+		}
+
+		for _, port := range process.Networking.GetPorts() {
+			if port.Protocol == protocol {
+				return nil
+			}
+		}
+
+		return errors.TriggerProtocolMismatchError(
+			string(process.Trigger.Kind), string(protocol), processLocation+".trigger.kind",
+		)
+	}
+}
+
+func validateCronTrigger(process *Process, processLocation string) error {
+	var totalError error
+
+	cron := process.Trigger.Cron
+	location := processLocation + ".trigger.cron"
+
+	if cron.Expression == "" {
+		totalError = errors.Join(totalError, errors.MissingRequiredFieldError(location+".expression"))
+	} else if _, err := cronutil.Parse(cron.Expression); err != nil {
+		totalError = errors.Join(totalError, errors.InvalidCronExpressionError(location+".expression", err))
+	}
+
+	// the local timezone is the one of the host running the process, which the KRT cannot know
+	if _, err := time.LoadLocation(cron.Timezone); err != nil || cron.Timezone == "Local" {
+		totalError = errors.Join(totalError, errors.InvalidTimezoneError(cron.Timezone, location+".timezone"))
+	}
+
+	return totalError
+}
+
+func validateObjectStoreTrigger(process *Process, processLocation string) error {
+	objectStore := process.Trigger.ObjectStore
+	location := processLocation + ".trigger.objectStore"
+
+	totalError := validateName(objectStore.Bucket, location+".bucket")
+
+	if len(objectStore.Events) == 0 {
+		totalError = errors.Join(totalError, errors.MissingRequiredFieldError(location+".events"))
+	}
+
+	for idx, event := range objectStore.Events {
+		if !event.IsValid() {
+			totalError = errors.Join(
+				totalError,
+				errors.InvalidObjectStoreEventError(fmt.Sprintf("%s.events[%d]", location, idx)),
+			)
+		}
+	}
+
+	return totalError
+}
+
+func isValidSubject(subject string) bool {
+	reSubject := regexp.MustCompile(`^[A-Za-z0-9_-]+(\.[A-Za-z0-9_-]+)*$`)
+	return reSubject.MatchString(subject)
+}
+
+func validateMessageQueueTrigger(process *Process, processLocation string) error {
+	var totalError error
+
+	messageQueue := process.Trigger.MessageQueue
+	location := processLocation + ".trigger.messageQueue"
+
+	if messageQueue.Subject == "" {
+		totalError = errors.Join(totalError, errors.MissingRequiredFieldError(location+".subject"))
+	} else if !isValidSubject(messageQueue.Subject) {
+		totalError = errors.Join(totalError, errors.InvalidMessageQueueSubjectError(location+".subject"))
+	}
+
+	if messageQueue.Queue != "" {
+		totalError = errors.Join(totalError, validateName(messageQueue.Queue, location+".queue"))
+	}
+
+	return totalError
+}
@@ -0,0 +1,161 @@
+//go:build unit
+
+package krt_test
+
+import (
+	"testing"
+	// timezones are validated against the embedded IANA database, so the tests do not depend on the host
+	_ "time/tzdata"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/konstellation-io/krt/internal/cronutil"
+	"github.com/konstellation-io/krt/pkg/errors"
+	"github.com/konstellation-io/krt/pkg/krt"
+)
+
+func TestProcess_ValidateTrigger(t *testing.T) {
+	grpcNetworking := &krt.ProcessNetworking{TargetPort: 9000, DestinationPort: 9000, Protocol: krt.NetworkingProtocolGRPC}
+
+	newTrigger := func(trigger *krt.ProcessTrigger) *ProcessBuilder {
+		return NewProcessBuilder().WithType(krt.ProcessTypeTrigger).WithTrigger(trigger)
+	}
+
+	testCases := []struct {
+		name          string
+		process       *krt.Process
+		expectedError error
+	}{
+		{
+			"valid grpc trigger",
+			newTrigger(&krt.ProcessTrigger{Kind: krt.TriggerKindGRPC}).WithNetworking(grpcNetworking).Build(),
+			nil,
+		},
+		{
+			"valid cron trigger",
+			newTrigger(&krt.ProcessTrigger{
+				Kind: krt.TriggerKindCron,
+				Cron: &krt.CronTrigger{Expression: "0 3 * * MON-FRI", Timezone: "UTC"},
+			}).Build(),
+			nil,
+		},
+		{
+			"valid object store trigger",
+			newTrigger(&krt.ProcessTrigger{
+				Kind: krt.TriggerKindObjectStore,
+				ObjectStore: &krt.ObjectStoreTrigger{
+					Bucket: "emails",
+					Events: []krt.ObjectStoreEvent{krt.ObjectStoreEventCreated},
+					Suffix: ".eml",
+				},
+			}).Build(),
+			nil,
+		},
+		{
+			"valid message queue trigger",
+			newTrigger(&krt.ProcessTrigger{
+				Kind:         krt.TriggerKindMessageQueue,
+				MessageQueue: &krt.MessageQueueTrigger{Subject: "emails.received", Queue: "classificator"},
+			}).Build(),
+			nil,
+		},
+		{
+			"trigger block in a task process",
+			NewProcessBuilder().WithTrigger(&krt.ProcessTrigger{Kind: krt.TriggerKindGRPC}).Build(),
+			errors.ErrTriggerNotAllowed,
+		},
+		{
+			"invalid trigger kind",
+			newTrigger(&krt.ProcessTrigger{Kind: "webhook"}).Build(),
+			errors.ErrInvalidTriggerKind,
+		},
+		{
+			"grpc trigger without networking",
+			newTrigger(&krt.ProcessTrigger{Kind: krt.TriggerKindGRPC}).Build(),
+			errors.ErrMissingRequiredField,
+		},
+		{
+			"http trigger without an HTTP port",
+			newTrigger(&krt.ProcessTrigger{Kind: krt.TriggerKindHTTP}).WithNetworking(grpcNetworking).Build(),
+			errors.ErrTriggerProtocolMismatch,
+		},
+		{
+			"cron trigger without cron block",
+			newTrigger(&krt.ProcessTrigger{Kind: krt.TriggerKindCron}).Build(),
+			errors.ErrMissingRequiredField,
+		},
+		{
+			"cron trigger with another kind block",
+			newTrigger(&krt.ProcessTrigger{
+				Kind:         krt.TriggerKindCron,
+				Cron:         &krt.CronTrigger{Expression: "@daily", Timezone: "UTC"},
+				MessageQueue: &krt.MessageQueueTrigger{Subject: "emails"},
+			}).Build(),
+			errors.ErrUnexpectedTriggerConfig,
+		},
+		{
+			"cron trigger with invalid expression",
+			newTrigger(&krt.ProcessTrigger{
+				Kind: krt.TriggerKindCron,
+				Cron: &krt.CronTrigger{Expression: "0 25 * * *", Timezone: "UTC"},
+			}).Build(),
+			cronutil.ErrInvalidField,
+		},
+		{
+			"cron trigger with invalid timezone",
+			newTrigger(&krt.ProcessTrigger{
+				Kind: krt.TriggerKindCron,
+				Cron: &krt.CronTrigger{Expression: "@hourly", Timezone: "Mars/Olympus_Mons"},
+			}).Build(),
+			errors.ErrInvalidTimezone,
+		},
+		{
+			"cron trigger with the host timezone",
+			newTrigger(&krt.ProcessTrigger{
+				Kind: krt.TriggerKindCron,
+				Cron: &krt.CronTrigger{Expression: "@hourly", Timezone: "Local"},
+			}).Build(),
+			errors.ErrInvalidTimezone,
+		},
+		{
+			"object store trigger without events",
+			newTrigger(&krt.ProcessTrigger{
+				Kind:        krt.TriggerKindObjectStore,
+				ObjectStore: &krt.ObjectStoreTrigger{Bucket: "emails"},
+			}).Build(),
+			errors.ErrMissingRequiredField,
+		},
+		{
+			"object store trigger with invalid event",
+			newTrigger(&krt.ProcessTrigger{
+				Kind:        krt.TriggerKindObjectStore,
+				ObjectStore: &krt.ObjectStoreTrigger{Bucket: "emails", Events: []krt.ObjectStoreEvent{"updated"}},
+			}).Build(),
+			errors.ErrInvalidObjectStoreEvent,
+		},
+		{
+			"message queue trigger with invalid subject",
+			newTrigger(&krt.ProcessTrigger{
+				Kind:         krt.TriggerKindMessageQueue,
+				MessageQueue: &krt.MessageQueueTrigger{Subject: "emails received"},
+			}).Build(),
+			errors.ErrInvalidMessageQueueSubject,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.ErrorIs(t, tc.process.ValidateTrigger(0, 0), tc.expectedError)
+		})
+	}
+
+	t.Run("cron expression error message", func(t *testing.T) {
+		process := newTrigger(&krt.ProcessTrigger{
+			Kind: krt.TriggerKindCron,
+			Cron: &krt.CronTrigger{Expression: "* * * *", Timezone: "UTC"},
+		}).Build()
+
+		assert.EqualError(t, process.ValidateTrigger(0, 0),
+			"invalid cron expression: krt.workflows[0].processes[0].trigger.cron.expression: malformed expression: expected 5 fields, found 4")
+	})
+}
@@ -11,6 +11,8 @@ func (workflow *Workflow) Validate(workflowIdx int) error {
 		workflow.ValidateName(workflowIdx),
 		workflow.ValidateType(workflowIdx),
 		workflow.ValidateVersionConfig(workflowIdx),
+		workflow.ValidateMetadata(workflowIdx),
+		workflow.ValidateDefaults(workflowIdx),
 		workflow.ValidateProcesses(workflowIdx),
 	)
 }
@@ -28,7 +30,11 @@ func (workflow *Workflow) ValidateType(workflowIdx int) error {
 }
 
 func (workflow *Workflow) ValidateVersionConfig(workflowIdx int) error {
-	return nil
+	return validateConfig(workflow.Config, fmt.Sprintf("krt.workflows[%d].config", workflowIdx))
+}
+
+func (workflow *Workflow) ValidateMetadata(workflowIdx int) error {
+	return validateMetadata(workflow.Labels, workflow.Annotations, fmt.Sprintf("krt.workflows[%d]", workflowIdx))
 }
 
 func (workflow *Workflow) ValidateProcesses(workflowIdx int) error {
@@ -41,10 +47,22 @@ func (workflow *Workflow) ValidateProcesses(workflowIdx int) error {
 		)
 	} else {
 		for idx, process := range workflow.Processes {
-			totalError = errors.Join(totalError, process.Validate(workflowIdx, idx))
+			if process.unexpanded {
+				continue
+			}
+
+			err := process.Validate(workflowIdx, idx)
+			if err != nil && process.Extends != "" {
+				err = errors.ExpandedProcessError(err, process.Extends)
+			}
+
+			totalError = errors.Join(totalError, err)
 		}
 
-		totalError = errors.Join(totalError, validateSubscritpionRelationships(workflow.Processes, workflowIdx))
+		totalError = errors.Join(totalError, validateSubscritpionRelationships(workflow.Processes, workflow.Name, workflowIdx))
+		totalError = errors.Join(totalError, validateDestinationPortDuplicates(workflow.Processes, workflowIdx))
+		totalError = errors.Join(totalError, validateExitTimeouts(workflow, workflowIdx))
+		totalError = errors.Join(totalError, validateWorkflowTypeRules(workflow, workflowIdx))
 	}
 
 	return totalError
@@ -69,3 +87,39 @@ func validateWorkflowDuplicates(workflows []Workflow) error {
 
 	return totalError
 }
+
+// validateDestinationPortDuplicates checks that no destination port is exposed twice inside a workflow.
+func validateDestinationPortDuplicates(processes []Process, workflowIdx int) error {
+	var totalError error
+
+	destinationPorts := make(map[int]string)
+
+	for processIdx, process := range processes {
+		if process.Networking == nil {
+			continue
+		}
+
+		for portIdx, port := range process.Networking.GetPorts() {
+			if port.DestinationPort == 0 {
+				continue
+			}
+
+			location := fmt.Sprintf("krt.workflows[%d].processes[%d].networking.destinationPort", workflowIdx, processIdx)
+			if len(process.Networking.Ports) > 0 {
+				location = fmt.Sprintf(
+					"krt.workflows[%d].processes[%d].networking.ports[%d].destinationPort", workflowIdx, processIdx, portIdx,
+				)
+			}
+
+			if previousLocation, ok := destinationPorts[port.DestinationPort]; ok {
+				totalError = errors.Join(totalError, errors.DuplicatedDestinationPortError(location, previousLocation))
+
+				continue
+			}
+
+			destinationPorts[port.DestinationPort] = location
+		}
+	}
+
+	return totalError
+}
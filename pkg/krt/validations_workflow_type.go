@@ -0,0 +1,105 @@
+package krt
+
+import (
+	"fmt"
+
+	"github.com/konstellation-io/krt/pkg/errors"
+)
+
+// workflowTypeRule is what a workflow type allows and requires from its processes.
+type workflowTypeRule struct {
+	// subscribesTo are the types of the workflows its processes can subscribe to.
+	subscribesTo []WorkflowType
+
+	allowJobs bool
+	allowGPU  bool
+
+	// requireNetworkedTrigger makes at least one trigger process with networking mandatory.
+	requireNetworkedTrigger bool
+	// requireCrossWorkflowSubscription makes at least one subscription to another workflow mandatory.
+	requireCrossWorkflowSubscription bool
+}
+
+func workflowTypeRules() map[WorkflowType]workflowTypeRule {
+	return map[WorkflowType]workflowTypeRule{
+		WorkflowTypeData: {
+			subscribesTo: []WorkflowType{WorkflowTypeData},
+			allowJobs:    true,
+			allowGPU:     true,
+		},
+		WorkflowTypeTraining: {
+			subscribesTo: []WorkflowType{WorkflowTypeData, WorkflowTypeFeedback},
+			allowJobs:    true,
+			allowGPU:     true,
+		},
+		WorkflowTypeFeedback: {
+			subscribesTo:                     []WorkflowType{WorkflowTypeServing, WorkflowTypeData, WorkflowTypeTraining},
+			requireCrossWorkflowSubscription: true,
+		},
+		WorkflowTypeServing: {
+			subscribesTo:            []WorkflowType{},
+			allowGPU:                true,
+			requireNetworkedTrigger: true,
+		},
+	}
+}
+
+func canSubscribeToWorkflow(workflowType, subscribedWorkflowType WorkflowType) bool {
+	for _, allowedType := range workflowTypeRules()[workflowType].subscribesTo {
+		if allowedType == subscribedWorkflowType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validateWorkflowTypeRules checks the processes of a workflow against the rules of its type.
+// Workflows of an unknown type are skipped, as the type itself is reported as invalid.
+func validateWorkflowTypeRules(workflow *Workflow, workflowIdx int) error {
+	rule, ok := workflowTypeRules()[workflow.Type]
+	if !ok {
+		return nil
+	}
+
+	var (
+		totalError                   error
+		hasNetworkedTrigger          bool
+		hasCrossWorkflowSubscription bool
+		workflowType                 = string(workflow.Type)
+	)
+
+	for processIdx, process := range workflow.Processes {
+		location := fmt.Sprintf("krt.workflows[%d].processes[%d]", workflowIdx, processIdx)
+
+		if process.IsJob() && !rule.allowJobs {
+			totalError = errors.Join(totalError, errors.JobNotAllowedInWorkflowError(workflowType, location+".mode"))
+		}
+
+		if process.GPU != nil && *process.GPU && !rule.allowGPU {
+			totalError = errors.Join(totalError, errors.GPUNotAllowedInWorkflowError(workflowType, location+".gpu"))
+		}
+
+		if process.Type == ProcessTypeTrigger && process.Networking != nil {
+			hasNetworkedTrigger = true
+		}
+
+		for _, subscription := range process.Subscriptions {
+			if parseSubscription(subscription).isCrossWorkflow(workflow.Name) {
+				hasCrossWorkflowSubscription = true
+			}
+		}
+	}
+
+	location := fmt.Sprintf("krt.workflows[%d].processes", workflowIdx)
+
+	if rule.requireNetworkedTrigger && !hasNetworkedTrigger {
+		totalError = errors.Join(totalError, errors.MissingNetworkedTriggerError(workflowType, location))
+	}
+
+	if rule.requireCrossWorkflowSubscription && !hasCrossWorkflowSubscription {
+		totalError = errors.Join(totalError, errors.MissingCrossWorkflowSubscriptionError(workflowType, location))
+	}
+
+	return totalError
+}
@@ -0,0 +1,203 @@
+package krt
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/konstellation-io/krt/pkg/errors"
+)
+
+// VersionPolicy sets which versions are accepted for a KRT.
+type VersionPolicy string
+
+const (
+	// VersionPolicyStrict only accepts release versions such as "v1.2.3".
+	VersionPolicyStrict VersionPolicy = "strict"
+	// VersionPolicySemVer accepts any semantic version, including prerelease and build
+	// identifiers such as "v1.2.3-rc.1+build.5".
+	VersionPolicySemVer VersionPolicy = "semver"
+
+	DefaultVersionPolicy = VersionPolicyStrict
+)
+
+// Version is a semantic version, as defined in https://semver.org, prefixed by "v".
+type Version struct {
+	Major      uint64
+	Minor      uint64
+	Patch      uint64
+	Prerelease []string
+	Build      []string
+}
+
+func semVerRegexp() *regexp.Regexp {
+	return regexp.MustCompile(
+		`^v(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)` +
+			`(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?` +
+			`(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`,
+	)
+}
+
+// ParseVersion parses a semantic version such as "v1.2.3", "v1.2.3-rc.1" or "v1.2.3+build.5".
+func ParseVersion(version string) (Version, error) {
+	matches := semVerRegexp().FindStringSubmatch(version)
+	if matches == nil {
+		return Version{}, errors.InvalidVersionError(version)
+	}
+
+	var (
+		parsed Version
+		err    error
+	)
+
+	for idx, number := range []*uint64{&parsed.Major, &parsed.Minor, &parsed.Patch} {
+		*number, err = strconv.ParseUint(matches[idx+1], 10, 64)
+		if err != nil {
+			return Version{}, errors.InvalidVersionError(version)
+		}
+	}
+
+	if matches[4] != "" {
+		parsed.Prerelease = strings.Split(matches[4], ".")
+	}
+
+	if matches[5] != "" {
+		parsed.Build = strings.Split(matches[5], ".")
+	}
+
+	return parsed, nil
+}
+
+func (v Version) String() string {
+	version := fmt.Sprintf("v%d.%d.%d", v.Major, v.Minor, v.Patch)
+
+	if len(v.Prerelease) > 0 {
+		version += "-" + strings.Join(v.Prerelease, ".")
+	}
+
+	if len(v.Build) > 0 {
+		version += "+" + strings.Join(v.Build, ".")
+	}
+
+	return version
+}
+
+// IsPrerelease returns whether the version has prerelease identifiers.
+func (v Version) IsPrerelease() bool {
+	return len(v.Prerelease) > 0
+}
+
+// Compare returns -1, 0 or 1 when the version has lower, equal or higher precedence than the other.
+// Build identifiers are ignored, as they do not affect precedence.
+func (v Version) Compare(other Version) int {
+	for _, numbers := range [][2]uint64{{v.Major, other.Major}, {v.Minor, other.Minor}, {v.Patch, other.Patch}} {
+		if numbers[0] != numbers[1] {
+			return compareNumbers(numbers[0], numbers[1])
+		}
+	}
+
+	return comparePrerelease(v.Prerelease, other.Prerelease)
+}
+
+// Less returns whether the version has lower precedence than the other.
+func (v Version) Less(other Version) bool {
+	return v.Compare(other) < 0
+}
+
+// BumpMajor returns the next major version. A prerelease of a major version, such as "v2.0.0-rc.1",
+// is bumped to its release.
+func (v Version) BumpMajor() Version {
+	if v.IsPrerelease() && v.Minor == 0 && v.Patch == 0 {
+		return Version{Major: v.Major}
+	}
+
+	return Version{Major: v.Major + 1}
+}
+
+// BumpMinor returns the next minor version. A prerelease of a minor version, such as "v1.3.0-rc.1",
+// is bumped to its release.
+func (v Version) BumpMinor() Version {
+	if v.IsPrerelease() && v.Patch == 0 {
+		return Version{Major: v.Major, Minor: v.Minor}
+	}
+
+	return Version{Major: v.Major, Minor: v.Minor + 1}
+}
+
+// BumpPatch returns the next patch version. A prerelease is bumped to its release.
+func (v Version) BumpPatch() Version {
+	if v.IsPrerelease() {
+		return Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch}
+	}
+
+	return Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch + 1}
+}
+
+// SortByVersion sorts the KRTs from the lowest to the highest version. KRTs with an invalid
+// version are placed at the end, keeping their relative order.
+func SortByVersion(krts []*Krt) {
+	sort.SliceStable(krts, func(i, j int) bool {
+		versionI, errI := ParseVersion(krts[i].Version)
+		versionJ, errJ := ParseVersion(krts[j].Version)
+
+		switch {
+		case errI != nil:
+			return false
+		case errJ != nil:
+			return true
+		default:
+			return versionI.Less(versionJ)
+		}
+	})
+}
+
+func compareNumbers(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// comparePrerelease compares prerelease identifiers, a version without them has higher precedence.
+func comparePrerelease(a, b []string) int {
+	switch {
+	case len(a) == 0 && len(b) == 0:
+		return 0
+	case len(a) == 0:
+		return 1
+	case len(b) == 0:
+		return -1
+	}
+
+	for idx := 0; idx < min(len(a), len(b)); idx++ {
+		if result := compareIdentifiers(a[idx], b[idx]); result != 0 {
+			return result
+		}
+	}
+
+	return compareNumbers(uint64(len(a)), uint64(len(b)))
+}
+
+// compareIdentifiers compares numeric identifiers numerically, and lower than alphanumeric ones,
+// which are compared lexically.
+func compareIdentifiers(a, b string) int {
+	numberA, errA := strconv.ParseUint(a, 10, 64)
+	numberB, errB := strconv.ParseUint(b, 10, 64)
+
+	switch {
+	case errA == nil && errB == nil:
+		return compareNumbers(numberA, numberB)
+	case errA == nil:
+		return -1
+	case errB == nil:
+		return 1
+	default:
+		return strings.Compare(a, b)
+	}
+}
@@ -0,0 +1,152 @@
+//go:build unit
+
+package krt_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/konstellation-io/krt/pkg/errors"
+	"github.com/konstellation-io/krt/pkg/krt"
+)
+
+func TestParseVersion(t *testing.T) {
+	testCases := []struct {
+		name     string
+		version  string
+		expected krt.Version
+		wantErr  bool
+	}{
+		{"release version", "v1.2.3", krt.Version{Major: 1, Minor: 2, Patch: 3}, false},
+		{
+			"prerelease version",
+			"v1.4.0-rc.1",
+			krt.Version{Major: 1, Minor: 4, Prerelease: []string{"rc", "1"}},
+			false,
+		},
+		{
+			"version with build metadata",
+			"v1.4.0-beta+build.5",
+			krt.Version{Major: 1, Minor: 4, Prerelease: []string{"beta"}, Build: []string{"build", "5"}},
+			false,
+		},
+		{"version without prefix", "1.2.3", krt.Version{}, true},
+		{"version with leading zeros", "v01.2.3", krt.Version{}, true},
+		{"prerelease with leading zeros", "v1.2.3-rc.01", krt.Version{}, true},
+		{"version with empty prerelease", "v1.2.3-", krt.Version{}, true},
+		{"incomplete version", "v1.2", krt.Version{}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			version, err := krt.ParseVersion(tc.version)
+			if tc.wantErr {
+				assert.ErrorIs(t, err, errors.ErrInvalidVersionTag)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, version)
+			assert.Equal(t, tc.version, version.String())
+		})
+	}
+}
+
+func TestVersion_Compare(t *testing.T) {
+	// Ordered by precedence, as in the example of the SemVer specification.
+	versions := []string{
+		"v1.0.0-alpha",
+		"v1.0.0-alpha.1",
+		"v1.0.0-alpha.beta",
+		"v1.0.0-beta",
+		"v1.0.0-beta.2",
+		"v1.0.0-beta.11",
+		"v1.0.0-rc.1",
+		"v1.0.0",
+		"v1.0.1",
+		"v1.1.0",
+		"v2.0.0",
+	}
+
+	for idx := 1; idx < len(versions); idx++ {
+		lower, err := krt.ParseVersion(versions[idx-1])
+		require.NoError(t, err)
+
+		higher, err := krt.ParseVersion(versions[idx])
+		require.NoError(t, err)
+
+		assert.True(t, lower.Less(higher), "%s < %s", lower, higher)
+		assert.Equal(t, 1, higher.Compare(lower), "%s > %s", higher, lower)
+	}
+
+	withBuild, err := krt.ParseVersion("v1.0.0+build.1")
+	require.NoError(t, err)
+
+	withoutBuild, err := krt.ParseVersion("v1.0.0")
+	require.NoError(t, err)
+
+	assert.Equal(t, 0, withBuild.Compare(withoutBuild), "build metadata does not affect precedence")
+}
+
+func TestVersion_Bump(t *testing.T) {
+	testCases := []struct {
+		version string
+		major   string
+		minor   string
+		patch   string
+	}{
+		{"v1.2.3", "v2.0.0", "v1.3.0", "v1.2.4"},
+		{"v1.2.3+build.1", "v2.0.0", "v1.3.0", "v1.2.4"},
+		{"v1.2.3-rc.1", "v2.0.0", "v1.3.0", "v1.2.3"},
+		{"v1.3.0-rc.1", "v2.0.0", "v1.3.0", "v1.3.0"},
+		{"v2.0.0-rc.1", "v2.0.0", "v2.0.0", "v2.0.0"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.version, func(t *testing.T) {
+			version, err := krt.ParseVersion(tc.version)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.major, version.BumpMajor().String())
+			assert.Equal(t, tc.minor, version.BumpMinor().String())
+			assert.Equal(t, tc.patch, version.BumpPatch().String())
+		})
+	}
+}
+
+func TestSortByVersion(t *testing.T) {
+	krts := []*krt.Krt{
+		NewKrtBuilder().WithVersion("v1.10.0").Build(),
+		NewKrtBuilder().WithVersion("invalid").Build(),
+		NewKrtBuilder().WithVersion("v1.2.0").Build(),
+		NewKrtBuilder().WithVersion("v1.10.0-rc.1").Build(),
+	}
+
+	krt.SortByVersion(krts)
+
+	versions := make([]string, 0, len(krts))
+	for _, sorted := range krts {
+		versions = append(versions, sorted.Version)
+	}
+
+	assert.Equal(t, []string{"v1.2.0", "v1.10.0-rc.1", "v1.10.0", "invalid"}, versions)
+}
+
+func TestKrt_Validate_VersionPolicy(t *testing.T) {
+	krtYaml := NewKrtBuilder().WithVersion("v1.4.0-rc.1").Build()
+
+	assert.NoError(t, krtYaml.Validate(krt.WithVersionPolicy(krt.VersionPolicySemVer)))
+
+	err := krtYaml.Validate()
+	assert.ErrorIs(t, err, errors.ErrVersionPrereleaseNotAllowed, "the default policy is strict")
+	assert.ErrorContains(t, err, errors.VersionPrereleaseNotAllowedError("krt.version").Error())
+
+	err = krtYaml.Validate(krt.WithVersionPolicy(krt.VersionPolicyStrict))
+	assert.ErrorIs(t, err, errors.ErrVersionPrereleaseNotAllowed)
+
+	err = NewKrtBuilder().Build().Validate(krt.WithVersionPolicy("latest"))
+	assert.ErrorIs(t, err, errors.ErrUnknownVersionPolicy)
+	assert.ErrorContains(t, err, errors.UnknownVersionPolicyError("latest").Error())
+}
@@ -0,0 +1,33 @@
+package parse
+
+import (
+	"io"
+	"os"
+
+	"github.com/konstellation-io/krt/pkg/bundle"
+	"github.com/konstellation-io/krt/pkg/errors"
+	"github.com/konstellation-io/krt/pkg/krt"
+)
+
+// ParseBundle parses the root KRT of a bundle, resolving its workflow includes from the files of the bundle.
+//
+// The checksums of the bundle are verified before parsing, see bundle.Read.
+func ParseBundle(reader io.Reader, opts ...Option) (*krt.Krt, error) {
+	krtBundle, err := bundle.Read(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseFS(krtBundle.FS(), krtBundle.Manifest.Root, nil, newOptions(opts))
+}
+
+// ParseBundleFile parses the root KRT of a given bundle filename, see ParseBundle.
+func ParseBundleFile(bundleFile string, opts ...Option) (*krt.Krt, error) {
+	file, err := os.Open(bundleFile)
+	if err != nil {
+		return nil, errors.ReadingFileError(err)
+	}
+	defer file.Close()
+
+	return ParseBundle(file, opts...)
+}
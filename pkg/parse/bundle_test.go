@@ -0,0 +1,35 @@
+//go:build unit
+
+package parse_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/konstellation-io/krt/pkg/bundle"
+	"github.com/konstellation-io/krt/pkg/errors"
+	"github.com/konstellation-io/krt/pkg/parse"
+)
+
+func TestParseBundle(t *testing.T) {
+	var packed bytes.Buffer
+
+	_, err := bundle.PackDir(&packed, "./testdata/includes", "krt.yaml")
+	require.NoError(t, err)
+
+	parsedKrt, err := parse.ParseBundle(&packed)
+	require.NoError(t, err)
+	require.NoError(t, parsedKrt.Validate())
+
+	assert.Len(t, parsedKrt.Workflows, 3)
+	assert.Equal(t, "workflows/serving.yaml", parsedKrt.Workflows[2].File, "includes are resolved from the bundle")
+}
+
+func TestParseBundleFile_NonExistentFile(t *testing.T) {
+	parsedKrt, err := parse.ParseBundleFile("./testdata/non_existent.krt")
+	assert.Nil(t, parsedKrt)
+	assert.ErrorIs(t, err, errors.ErrReadingFile)
+}
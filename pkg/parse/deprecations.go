@@ -0,0 +1,152 @@
+package parse
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/konstellation-io/krt/pkg/errors"
+)
+
+// Deprecation marks a field, or one of its values, as deprecated.
+type Deprecation struct {
+	// Path of the deprecated field, with "[*]" matching any index, such as
+	// "krt.workflows[*].processes[*].networking.targetPort".
+	Path string
+	// Value, if set, only deprecates the field when it has this value.
+	Value string
+	// Replacement is a hint on what to use instead.
+	Replacement string
+	// RemovedIn is the API version in which the field will be removed.
+	RemovedIn string
+}
+
+// DeprecationWarning is a deprecated field found parsing a KRT.
+type DeprecationWarning struct {
+	Path        string
+	Deprecation Deprecation
+}
+
+func (w DeprecationWarning) String() string {
+	field := w.Path
+	if w.Deprecation.Value != "" {
+		field = fmt.Sprintf("%s: %s", w.Path, w.Deprecation.Value)
+	}
+
+	return fmt.Sprintf("%s is deprecated and will be removed in %s, use %s instead",
+		field, w.Deprecation.RemovedIn, w.Deprecation.Replacement)
+}
+
+func (w DeprecationWarning) error() error {
+	return errors.DeprecatedFieldError(w.Path, w.Deprecation.Replacement, w.Deprecation.RemovedIn)
+}
+
+// deprecationRegistry holds the deprecations of the current API version and the ones registered by library users.
+//
+//nolint:gochecknoglobals // deprecations can be registered by library users
+var deprecationRegistry = struct {
+	sync.RWMutex
+	deprecations []Deprecation
+}{
+	deprecations: builtinDeprecations(),
+}
+
+// builtinDeprecations returns the fields deprecated in the current API version: the single port networking
+// form, replaced by the list of ports. It is still supported, so it is written out only when it is used.
+func builtinDeprecations() []Deprecation {
+	deprecations := make([]Deprecation, 0, 3)
+	for _, field := range []string{"targetPort", "destinationPort", "protocol"} {
+		deprecations = append(deprecations, Deprecation{
+			Path:        "krt.workflows[*].processes[*].networking." + field,
+			Replacement: "networking.ports",
+			RemovedIn:   "krt/v2",
+		})
+	}
+
+	return deprecations
+}
+
+// RegisterDeprecation marks the given fields or values as deprecated.
+func RegisterDeprecation(deprecations ...Deprecation) {
+	deprecationRegistry.Lock()
+	defer deprecationRegistry.Unlock()
+
+	deprecationRegistry.deprecations = append(deprecationRegistry.deprecations, deprecations...)
+}
+
+// UnregisterDeprecation removes the given deprecations, registered with RegisterDeprecation.
+func UnregisterDeprecation(deprecations ...Deprecation) {
+	deprecationRegistry.Lock()
+	defer deprecationRegistry.Unlock()
+
+	registered := deprecationRegistry.deprecations[:0]
+
+	for _, deprecation := range deprecationRegistry.deprecations {
+		if !slices.Contains(deprecations, deprecation) {
+			registered = append(registered, deprecation)
+		}
+	}
+
+	deprecationRegistry.deprecations = registered
+}
+
+// Deprecations returns the registered deprecations.
+func Deprecations() []Deprecation {
+	deprecationRegistry.RLock()
+	defer deprecationRegistry.RUnlock()
+
+	return append([]Deprecation(nil), deprecationRegistry.deprecations...)
+}
+
+// findDeprecations returns a warning for every deprecated field of the document, in document order.
+func findDeprecations(root *yaml.Node) []DeprecationWarning {
+	if root.Kind != yaml.DocumentNode || len(root.Content) == 0 {
+		return nil
+	}
+
+	deprecations := Deprecations()
+
+	patterns := make([]*regexp.Regexp, 0, len(deprecations))
+	for _, deprecation := range deprecations {
+		pattern := strings.ReplaceAll(regexp.QuoteMeta(deprecation.Path), `\[\*\]`, `\[\d+\]`)
+		patterns = append(patterns, regexp.MustCompile("^"+pattern+"$"))
+	}
+
+	var warnings []DeprecationWarning
+
+	walkNodes(root.Content[0], "krt", func(path string, node *yaml.Node) {
+		for idx, deprecation := range deprecations {
+			if !patterns[idx].MatchString(path) {
+				continue
+			}
+
+			if deprecation.Value != "" && (node.Kind != yaml.ScalarNode || node.Value != deprecation.Value) {
+				continue
+			}
+
+			warnings = append(warnings, DeprecationWarning{Path: path, Deprecation: deprecation})
+		}
+	})
+
+	return warnings
+}
+
+// walkNodes calls the function with the path of every node below the given one, the node included.
+func walkNodes(node *yaml.Node, path string, fn func(path string, node *yaml.Node)) {
+	fn(path, node)
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		for idx := 0; idx+1 < len(node.Content); idx += 2 {
+			walkNodes(node.Content[idx+1], path+"."+node.Content[idx].Value, fn)
+		}
+	case yaml.SequenceNode:
+		for idx, item := range node.Content {
+			walkNodes(item, fmt.Sprintf("%s[%d]", path, idx), fn)
+		}
+	}
+}
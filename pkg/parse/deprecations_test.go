@@ -0,0 +1,149 @@
+//go:build unit
+
+package parse_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/konstellation-io/krt/pkg/errors"
+	"github.com/konstellation-io/krt/pkg/parse"
+)
+
+const singlePortKrt = `
+apiVersion: krt/v1
+workflows:
+  - name: workflow
+    processes:
+      - name: entrypoint
+        networking:
+          targetPort: 9000
+          protocol: GRPC
+      - name: exitpoint
+        networking:
+          ports:
+            - name: http
+              targetPort: 9001
+`
+
+const portsKrt = `
+apiVersion: krt/v1
+workflows:
+  - name: workflow
+    processes:
+      - name: entrypoint
+        networking:
+          ports:
+            - name: grpc
+              targetPort: 9000
+              protocol: GRPC
+`
+
+func registerDeprecations(t *testing.T, deprecations ...parse.Deprecation) {
+	t.Helper()
+
+	parse.RegisterDeprecation(deprecations...)
+	t.Cleanup(func() { parse.UnregisterDeprecation(deprecations...) })
+}
+
+func collectDeprecations(warnings *[]parse.DeprecationWarning) parse.Option {
+	return parse.WithDeprecationHandler(func(warning parse.DeprecationWarning) {
+		*warnings = append(*warnings, warning)
+	})
+}
+
+func TestParseYamlToKrt_DeprecationWarnings(t *testing.T) {
+	var warnings []parse.DeprecationWarning
+
+	_, err := parse.ParseYamlToKrt([]byte(singlePortKrt), collectDeprecations(&warnings))
+	require.NoError(t, err)
+
+	paths := make([]string, 0, len(warnings))
+	for _, warning := range warnings {
+		paths = append(paths, warning.Path)
+		assert.Equal(t, "networking.ports", warning.Deprecation.Replacement)
+	}
+
+	assert.Equal(t, []string{
+		"krt.workflows[0].processes[0].networking.targetPort",
+		"krt.workflows[0].processes[0].networking.protocol",
+	}, paths)
+	assert.Equal(t,
+		"krt.workflows[0].processes[0].networking.targetPort is deprecated and will be removed in krt/v2, "+
+			"use networking.ports instead",
+		warnings[0].String(),
+	)
+}
+
+func TestParseYamlToKrt_StrictDeprecations(t *testing.T) {
+	parsedKrt, err := parse.ParseYamlToKrt([]byte(singlePortKrt), parse.WithStrictDeprecations())
+	assert.Nil(t, parsedKrt)
+	assert.ErrorIs(t, err, errors.ErrDeprecatedField)
+	assert.ErrorContains(t, err, errors.DeprecatedFieldError(
+		"krt.workflows[0].processes[0].networking.protocol", "networking.ports", "krt/v2",
+	).Error())
+
+	_, err = parse.ParseFileToKrt("./testdata/correct_krt.yaml", parse.WithStrictDeprecations())
+	assert.ErrorIs(t, err, errors.ErrDeprecatedField, "the single port form is deprecated")
+
+	_, err = parse.ParseYamlToKrt([]byte(portsKrt), parse.WithStrictDeprecations())
+	assert.NoError(t, err)
+}
+
+func TestRegisterDeprecation_Value(t *testing.T) {
+	registerDeprecations(t, parse.Deprecation{
+		Path:        "krt.workflows[*].processes[*].objectStore.scope",
+		Value:       "legacy",
+		Replacement: "objectStore.scope: product",
+		RemovedIn:   "krt/v2",
+	})
+
+	var warnings []parse.DeprecationWarning
+
+	_, err := parse.ParseYamlToKrt([]byte(`
+apiVersion: krt/v1
+workflows:
+  - name: workflow
+    processes:
+      - name: etl
+        objectStore: {name: emails, scope: legacy}
+      - name: storer
+        objectStore: {name: emails, scope: product}
+`), collectDeprecations(&warnings))
+	require.NoError(t, err)
+
+	require.Len(t, warnings, 1)
+	assert.Equal(t, "krt.workflows[0].processes[0].objectStore.scope", warnings[0].Path)
+	assert.Contains(t, warnings[0].String(), "krt.workflows[0].processes[0].objectStore.scope: legacy is deprecated")
+}
+
+func TestUnregisterDeprecation(t *testing.T) {
+	builtinDeprecations := parse.Deprecations()
+	deprecation := parse.Deprecation{
+		Path:        "krt.workflows[*].processes[*].networking.ports[*].name",
+		Replacement: "networking.ports[*].targetPort",
+		RemovedIn:   "krt/v2",
+	}
+
+	parse.RegisterDeprecation(deprecation)
+	parse.UnregisterDeprecation(deprecation)
+
+	assert.Equal(t, builtinDeprecations, parse.Deprecations())
+
+	_, err := parse.ParseYamlToKrt([]byte(portsKrt), parse.WithStrictDeprecations())
+	assert.NoError(t, err)
+}
+
+func TestParseKrtToYaml_StrictDeprecationsRoundTrip(t *testing.T) {
+	parsedKrt, err := parse.ParseYamlToKrt([]byte(portsKrt), parse.WithStrictDeprecations())
+	require.NoError(t, err)
+
+	krtYaml, err := parse.ParseKrtToYaml(parsedKrt)
+	require.NoError(t, err)
+
+	reparsedKrt, err := parse.ParseYamlToKrt(krtYaml, parse.WithStrictDeprecations())
+	require.NoError(t, err, "deprecated fields are not written out")
+	assert.Equal(t, parsedKrt.Workflows[0].Processes[0].Networking, reparsedKrt.Workflows[0].Processes[0].Networking)
+}
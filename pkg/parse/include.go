@@ -0,0 +1,158 @@
+package parse
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/konstellation-io/krt/pkg/errors"
+)
+
+// includeLoader reads the files included by a KRT from the file system it was read from.
+type includeLoader struct {
+	fsys     fs.FS
+	dir      string
+	rootFile string
+	maxSize  int64
+	// included are the files already included, with the file including them. A file can only be included once,
+	// so including the same files over and over does not make parsing read an unbounded amount of data.
+	included map[string]string
+	// documents, if set, collects the document of every included file, by path.
+	documents map[string]*yaml.Node
+}
+
+// resolveIncludes replaces the include entries of the document workflows by the workflows of the included files.
+// It returns the file each workflow comes from, empty for the workflows declared in the root file.
+func resolveIncludes(root *yaml.Node, loader *includeLoader) ([]string, error) {
+	if root.Kind != yaml.DocumentNode || len(root.Content) == 0 {
+		return nil, nil
+	}
+
+	workflows := mappingValue(root.Content[0], "workflows")
+	if workflows == nil || workflows.Kind != yaml.SequenceNode {
+		return nil, nil
+	}
+
+	var chain []string
+	if loader != nil {
+		chain = []string{loader.rootFile}
+	}
+
+	items, files, err := loader.resolveWorkflows(workflows.Content, "", chain)
+	if err != nil {
+		return nil, err
+	}
+
+	workflows.Content = items
+
+	return files, nil
+}
+
+// resolveWorkflows returns the given workflows with their include entries resolved, along with the file
+// each workflow comes from. Chain are the files including the current one, to detect cycles.
+func (l *includeLoader) resolveWorkflows(items []*yaml.Node, file string, chain []string) ([]*yaml.Node, []string, error) {
+	var (
+		totalError error
+		resolved   = make([]*yaml.Node, 0, len(items))
+		files      = make([]string, 0, len(items))
+	)
+
+	for _, item := range items {
+		include := mappingValue(item, "include")
+		if include == nil {
+			resolved = append(resolved, item)
+			files = append(files, file)
+
+			continue
+		}
+
+		includedItems, includedFiles, err := l.include(item, include, file, chain)
+		if err != nil {
+			totalError = errors.Join(totalError, err)
+			continue
+		}
+
+		resolved = append(resolved, includedItems...)
+		files = append(files, includedFiles...)
+	}
+
+	return resolved, files, totalError
+}
+
+// include reads the workflows of the file included by the given entry of the given file.
+func (l *includeLoader) include(item, include *yaml.Node, file string, chain []string) ([]*yaml.Node, []string, error) {
+	if l == nil {
+		return nil, nil, errors.InvalidIncludeError(include.Value, "includes are only resolved from a file system, see ParseFS and MigrateFS")
+	}
+
+	source := file
+	if source == "" {
+		source = l.rootFile
+	}
+
+	if len(item.Content) != 2 || include.Kind != yaml.ScalarNode {
+		return nil, nil, errors.InvalidIncludeError(source, "an include entry must only have the path of the included file")
+	}
+
+	includedFile, err := l.resolvePath(include.Value, source)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, visited := range chain {
+		if visited == includedFile {
+			return nil, nil, errors.IncludeCycleError(strings.Join(append(chain, includedFile), " -> "))
+		}
+	}
+
+	if includedBy, ok := l.included[includedFile]; ok {
+		return nil, nil, errors.InvalidIncludeError(source, fmt.Sprintf("%s is already included by %s", includedFile, includedBy))
+	}
+
+	l.included[includedFile] = source
+
+	content, err := readFile(l.fsys, includedFile, l.maxSize)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", source, err)
+	}
+
+	var includedRoot yaml.Node
+
+	if err := yaml.Unmarshal(content, &includedRoot); err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", includedFile, errors.InvalidYamlError(err))
+	}
+
+	if len(includedRoot.Content) == 0 {
+		return nil, nil, errors.InvalidIncludeError(includedFile, "the file is empty")
+	}
+
+	if l.documents != nil {
+		l.documents[includedFile] = &includedRoot
+	}
+
+	switch node := includedRoot.Content[0]; node.Kind {
+	case yaml.MappingNode:
+		return l.resolveWorkflows([]*yaml.Node{node}, includedFile, append(chain, includedFile))
+	case yaml.SequenceNode:
+		return l.resolveWorkflows(node.Content, includedFile, append(chain, includedFile))
+	default:
+		return nil, nil, errors.InvalidIncludeError(includedFile, "it must be a workflow or a list of workflows")
+	}
+}
+
+// resolvePath returns the path of an included file in the file system, which must be inside the directory
+// of the root file.
+func (l *includeLoader) resolvePath(include, source string) (string, error) {
+	resolved := path.Join(l.dir, include)
+
+	isOutside := path.IsAbs(include) || !fs.ValidPath(resolved) ||
+		(l.dir != "." && !strings.HasPrefix(resolved, l.dir+"/"))
+	if isOutside {
+		return "", errors.IncludeOutsideRootError(include, source)
+	}
+
+	return resolved, nil
+}
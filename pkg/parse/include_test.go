@@ -0,0 +1,157 @@
+//go:build unit
+
+package parse_test
+
+import (
+	"os"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/konstellation-io/krt/pkg/errors"
+	"github.com/konstellation-io/krt/pkg/parse"
+)
+
+func TestParseFS(t *testing.T) {
+	parsedKrt, err := parse.ParseFS(os.DirFS("./testdata/includes"), "krt.yaml")
+	require.NoError(t, err)
+	require.NoError(t, parsedKrt.Validate())
+
+	require.Len(t, parsedKrt.Workflows, 3)
+
+	assert.Equal(t, "py-classificator", parsedKrt.Workflows[0].Name)
+	assert.Equal(t, "workflows/py-classificator.yaml", parsedKrt.Workflows[0].File)
+	assert.Equal(t, "go-classificator", parsedKrt.Workflows[1].Name)
+	assert.Equal(t, "workflows/others.yaml", parsedKrt.Workflows[1].File)
+	assert.Equal(t, "serving", parsedKrt.Workflows[2].Name)
+	assert.Equal(t, "workflows/serving.yaml", parsedKrt.Workflows[2].File, "paths are relative to the root file")
+
+	assert.Equal(t, 1, *parsedKrt.Workflows[2].Processes[0].Replicas, "defaults are set to included workflows")
+}
+
+func TestParseFS_ValidationErrorsNameTheFile(t *testing.T) {
+	fsys := fstest.MapFS{
+		"krt.yaml":      {Data: []byte("workflows:\n  - name: root\n  - include: workflow.yaml\n")},
+		"workflow.yaml": {Data: []byte("name: Invalid\n")},
+	}
+
+	parsedKrt, err := parse.ParseFS(fsys, "krt.yaml")
+	require.NoError(t, err)
+
+	assert.Empty(t, parsedKrt.Workflows[0].File)
+
+	err = parsedKrt.Validate()
+	assert.ErrorContains(t, err, errors.InvalidFieldNameError("krt.workflows[1].name").Error()+
+		`, in workflow included from "workflow.yaml"`)
+	assert.NotContains(t, err.Error(), `krt.workflows[0].name, in workflow included from`)
+}
+
+func TestParseFS_Errors(t *testing.T) {
+	testCases := []struct {
+		name        string
+		fsys        fstest.MapFS
+		krtFile     string
+		errorType   error
+		errorString string
+	}{
+		{
+			name: "files including each other",
+			fsys: fstest.MapFS{
+				"krt.yaml":    {Data: []byte("workflows:\n  - include: first.yaml\n")},
+				"first.yaml":  {Data: []byte("- include: second.yaml\n")},
+				"second.yaml": {Data: []byte("- include: first.yaml\n")},
+			},
+			krtFile:     "krt.yaml",
+			errorType:   errors.ErrIncludeCycle,
+			errorString: "krt.yaml -> first.yaml -> second.yaml -> first.yaml",
+		},
+		{
+			name: "file including the root file",
+			fsys: fstest.MapFS{
+				"krt.yaml": {Data: []byte("workflows:\n  - include: krt.yaml\n")},
+			},
+			krtFile:     "krt.yaml",
+			errorType:   errors.ErrIncludeCycle,
+			errorString: "krt.yaml -> krt.yaml",
+		},
+		{
+			name: "file outside the directory of the root file",
+			fsys: fstest.MapFS{
+				"product/krt.yaml":     {Data: []byte("workflows:\n  - include: ../shared/workflow.yaml\n")},
+				"shared/workflow.yaml": {Data: []byte("name: workflow\n")},
+			},
+			krtFile:     "product/krt.yaml",
+			errorType:   errors.ErrIncludeOutsideRoot,
+			errorString: errors.IncludeOutsideRootError("../shared/workflow.yaml", "product/krt.yaml").Error(),
+		},
+		{
+			name: "absolute path",
+			fsys: fstest.MapFS{
+				"krt.yaml": {Data: []byte("workflows:\n  - include: /etc/workflow.yaml\n")},
+			},
+			krtFile:     "krt.yaml",
+			errorType:   errors.ErrIncludeOutsideRoot,
+			errorString: errors.IncludeOutsideRootError("/etc/workflow.yaml", "krt.yaml").Error(),
+		},
+		{
+			name: "non existent file",
+			fsys: fstest.MapFS{
+				"krt.yaml": {Data: []byte("workflows:\n  - include: workflow.yaml\n")},
+			},
+			krtFile:     "krt.yaml",
+			errorType:   errors.ErrReadingFile,
+			errorString: "workflow.yaml",
+		},
+		{
+			name: "file included twice",
+			fsys: fstest.MapFS{
+				"krt.yaml":      {Data: []byte("workflows:\n  - include: left.yaml\n  - include: right.yaml\n")},
+				"left.yaml":     {Data: []byte("- include: workflow.yaml\n")},
+				"right.yaml":    {Data: []byte("- include: workflow.yaml\n")},
+				"workflow.yaml": {Data: []byte("name: workflow\n")},
+			},
+			krtFile:     "krt.yaml",
+			errorType:   errors.ErrInvalidInclude,
+			errorString: errors.InvalidIncludeError("right.yaml", "workflow.yaml is already included by left.yaml").Error(),
+		},
+		{
+			name: "include entry with other fields",
+			fsys: fstest.MapFS{
+				"krt.yaml":      {Data: []byte("workflows:\n  - include: workflow.yaml\n    name: workflow\n")},
+				"workflow.yaml": {Data: []byte("name: workflow\n")},
+			},
+			krtFile:   "krt.yaml",
+			errorType: errors.ErrInvalidInclude,
+			errorString: errors.InvalidIncludeError(
+				"krt.yaml", "an include entry must only have the path of the included file",
+			).Error(),
+		},
+		{
+			name: "included file that is not a workflow",
+			fsys: fstest.MapFS{
+				"krt.yaml":      {Data: []byte("workflows:\n  - include: workflow.yaml\n")},
+				"workflow.yaml": {Data: []byte("workflow\n")},
+			},
+			krtFile:     "krt.yaml",
+			errorType:   errors.ErrInvalidInclude,
+			errorString: errors.InvalidIncludeError("workflow.yaml", "it must be a workflow or a list of workflows").Error(),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			parsedKrt, err := parse.ParseFS(tc.fsys, tc.krtFile)
+			assert.Nil(t, parsedKrt)
+			assert.ErrorIs(t, err, tc.errorType)
+			assert.ErrorContains(t, err, tc.errorString)
+		})
+	}
+}
+
+func TestParseYamlToKrt_Include(t *testing.T) {
+	parsedKrt, err := parse.ParseYamlToKrt([]byte("workflows:\n  - include: workflow.yaml\n"))
+	assert.Nil(t, parsedKrt)
+	assert.ErrorIs(t, err, errors.ErrInvalidInclude)
+}
@@ -0,0 +1,317 @@
+package parse
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/konstellation-io/krt/pkg/errors"
+	"github.com/konstellation-io/krt/pkg/krt"
+)
+
+const yamlIndent = 2
+
+// Rewrite is a change made to a KRT migrating it to a newer API version.
+type Rewrite struct {
+	Path        string
+	Description string
+	// File is the file the rewritten workflow was included from, empty for the root file.
+	File string
+}
+
+func (r Rewrite) String() string {
+	if r.File != "" {
+		return fmt.Sprintf("%s: %s, in workflow included from %q", r.Path, r.Description, r.File)
+	}
+
+	return fmt.Sprintf("%s: %s", r.Path, r.Description)
+}
+
+// migration upgrades a KRT document from an API version to the next one. Files are the files each
+// workflow of the document was included from, see resolveIncludes.
+type migration struct {
+	from    string
+	to      string
+	migrate func(document *yaml.Node, files []string) []Rewrite
+}
+
+// migrations lists the steps to upgrade a KRT to the current API version, in order.
+func migrations() []migration {
+	return []migration{
+		{from: krt.APIVersionV1Alpha1, to: krt.APIVersionV1, migrate: declareSubscribedSubtopics},
+	}
+}
+
+// Migrate upgrades a KRT to the current API version, returning the migrated yaml and the rewrites made.
+// KRTs already in the current API version are returned as they are.
+//
+// The workflows a KRT includes are migrated along with it, so KRTs with include entries must be migrated
+// with MigrateFS.
+func Migrate(krtYaml []byte) ([]byte, []Rewrite, error) {
+	var root yaml.Node
+
+	if err := yaml.Unmarshal(krtYaml, &root); err != nil {
+		return nil, nil, errors.InvalidYamlError(err)
+	}
+
+	if _, err := resolveIncludes(&root, nil); err != nil {
+		return nil, nil, err
+	}
+
+	rewrites, err := migrateDocument(&root, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(rewrites) == 0 {
+		return krtYaml, nil, nil
+	}
+
+	migratedYaml, err := encodeDocument(&root)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return migratedYaml, rewrites, nil
+}
+
+// MigrateFS upgrades a KRT file of the given file system, and the files it includes, to the current API version.
+// It returns the migrated content of the files rewritten, by path in the file system, and the rewrites made.
+// Include entries are kept, the included workflows are migrated in their own files.
+func MigrateFS(fsys fs.FS, krtFile string, opts ...Option) (map[string][]byte, []Rewrite, error) {
+	parseOptions := newOptions(opts)
+
+	krtYaml, err := readFile(fsys, krtFile, parseOptions.maxSize)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var root yaml.Node
+
+	if err := yaml.Unmarshal(krtYaml, &root); err != nil {
+		return nil, nil, errors.InvalidYamlError(err)
+	}
+
+	loader := &includeLoader{
+		fsys:      fsys,
+		dir:       path.Dir(krtFile),
+		rootFile:  krtFile,
+		maxSize:   parseOptions.maxSize,
+		included:  make(map[string]string),
+		documents: make(map[string]*yaml.Node),
+	}
+
+	// included workflows are migrated in place in the documents of their files, so the root file is
+	// written back with its own workflows and include entries
+	workflows := mappingValue(documentContent(&root), "workflows")
+
+	var rootWorkflows []*yaml.Node
+	if workflows != nil {
+		rootWorkflows = workflows.Content
+	}
+
+	workflowFiles, err := resolveIncludes(&root, loader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rewrites, err := migrateDocument(&root, workflowFiles)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if workflows != nil {
+		workflows.Content = rootWorkflows
+	}
+
+	migratedFiles := make(map[string][]byte)
+
+	for _, rewrite := range rewrites {
+		file, document := krtFile, &root
+		if rewrite.File != "" {
+			file, document = rewrite.File, loader.documents[rewrite.File]
+		}
+
+		if _, ok := migratedFiles[file]; ok {
+			continue
+		}
+
+		if migratedFiles[file], err = encodeDocument(document); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return migratedFiles, rewrites, nil
+}
+
+func encodeDocument(root *yaml.Node) ([]byte, error) {
+	var encoded bytes.Buffer
+
+	encoder := yaml.NewEncoder(&encoded)
+	encoder.SetIndent(yamlIndent)
+
+	if err := encoder.Encode(root); err != nil {
+		return nil, errors.InvalidYamlError(err)
+	}
+
+	return encoded.Bytes(), nil
+}
+
+// documentContent returns the top level node of a document, nil if it is empty.
+func documentContent(root *yaml.Node) *yaml.Node {
+	if root.Kind != yaml.DocumentNode || len(root.Content) == 0 {
+		return nil
+	}
+
+	return root.Content[0]
+}
+
+// migrateDocument applies in place every migration needed to upgrade the document to the current API version.
+// Files are the files each workflow of the document was included from, see resolveIncludes.
+func migrateDocument(root *yaml.Node, files []string) ([]Rewrite, error) {
+	if root.Kind != yaml.DocumentNode || len(root.Content) == 0 || root.Content[0].Kind != yaml.MappingNode {
+		return nil, nil
+	}
+
+	document := root.Content[0]
+
+	apiVersion := krt.APIVersionV1Alpha1
+	if apiVersionNode := mappingValue(document, "apiVersion"); apiVersionNode != nil {
+		apiVersion = apiVersionNode.Value
+	}
+
+	if apiVersion == krt.CurrentAPIVersion {
+		return nil, nil
+	}
+
+	var rewrites []Rewrite
+
+	for _, step := range migrations() {
+		if step.from != apiVersion {
+			continue
+		}
+
+		rewrites = append(rewrites, step.migrate(document, files)...)
+		rewrites = append(rewrites, setAPIVersion(document, step.to))
+		apiVersion = step.to
+	}
+
+	if apiVersion != krt.CurrentAPIVersion {
+		return nil, errors.UnsupportedAPIVersionError(apiVersion, "krt.apiVersion")
+	}
+
+	return rewrites, nil
+}
+
+// declareSubscribedSubtopics declares the subtopics subscribed to in the same workflow, such as
+// "email-classificator.repairs", as before krt/v1 subtopics did not need to be declared.
+func declareSubscribedSubtopics(document *yaml.Node, files []string) []Rewrite {
+	var rewrites []Rewrite
+
+	for workflowIdx, workflow := range sequenceItems(mappingValue(document, "workflows")) {
+		processes := sequenceItems(mappingValue(workflow, "processes"))
+
+		processesByNames := make(map[string]int, len(processes))
+		for processIdx, process := range processes {
+			if name := mappingValue(process, "name"); name != nil {
+				processesByNames[name.Value] = processIdx
+			}
+		}
+
+		for _, process := range processes {
+			for _, subscription := range sequenceItems(mappingValue(process, "subscriptions")) {
+				subscribed, subtopic, ok := strings.Cut(subscription.Value, ".")
+				if !ok || subtopic == "" || strings.Contains(subscribed, "/") {
+					continue
+				}
+
+				subscribedIdx, exists := processesByNames[subscribed]
+				if !exists || declareSubtopic(processes[subscribedIdx], subtopic) {
+					continue
+				}
+
+				rewrite := Rewrite{
+					Path:        fmt.Sprintf("krt.workflows[%d].processes[%d].subtopics", workflowIdx, subscribedIdx),
+					Description: fmt.Sprintf("declared subtopic %q subscribed to by another process", subtopic),
+				}
+				if workflowIdx < len(files) {
+					rewrite.File = files[workflowIdx]
+				}
+
+				rewrites = append(rewrites, rewrite)
+			}
+		}
+	}
+
+	return rewrites
+}
+
+// declareSubtopic adds the subtopic to the subtopics of a process, returning whether it was already declared.
+func declareSubtopic(process *yaml.Node, subtopic string) bool {
+	subtopics := mappingValue(process, "subtopics")
+	if subtopics == nil || subtopics.Kind != yaml.SequenceNode {
+		subtopics = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		setMappingValue(process, "subtopics", subtopics)
+	}
+
+	for _, declared := range subtopics.Content {
+		if declared.Value == subtopic {
+			return true
+		}
+	}
+
+	subtopics.Content = append(subtopics.Content, scalarNode(subtopic))
+
+	return false
+}
+
+func setAPIVersion(document *yaml.Node, apiVersion string) Rewrite {
+	if apiVersionNode := mappingValue(document, "apiVersion"); apiVersionNode != nil {
+		apiVersionNode.Value = apiVersion
+	} else {
+		document.Content = append([]*yaml.Node{scalarNode("apiVersion"), scalarNode(apiVersion)}, document.Content...)
+	}
+
+	return Rewrite{Path: "krt.apiVersion", Description: fmt.Sprintf("set to %q", apiVersion)}
+}
+
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for idx := 0; idx+1 < len(node.Content); idx += 2 {
+		if node.Content[idx].Value == key {
+			return node.Content[idx+1]
+		}
+	}
+
+	return nil
+}
+
+func setMappingValue(node *yaml.Node, key string, value *yaml.Node) {
+	for idx := 0; idx+1 < len(node.Content); idx += 2 {
+		if node.Content[idx].Value == key {
+			node.Content[idx+1] = value
+			return
+		}
+	}
+
+	node.Content = append(node.Content, scalarNode(key), value)
+}
+
+func sequenceItems(node *yaml.Node) []*yaml.Node {
+	if node == nil || node.Kind != yaml.SequenceNode {
+		return nil
+	}
+
+	return node.Content
+}
+
+func scalarNode(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+}
@@ -0,0 +1,127 @@
+//go:build unit
+
+package parse_test
+
+import (
+	"os"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/konstellation-io/krt/pkg/errors"
+	"github.com/konstellation-io/krt/pkg/krt"
+	"github.com/konstellation-io/krt/pkg/parse"
+)
+
+func TestMigrate(t *testing.T) {
+	legacyYaml, err := os.ReadFile("./testdata/v1alpha1_krt.yaml")
+	require.NoError(t, err)
+
+	migratedYaml, rewrites, err := parse.Migrate(legacyYaml)
+	require.NoError(t, err)
+
+	assert.Equal(t, []parse.Rewrite{
+		{
+			Path:        "krt.workflows[0].processes[2].subtopics",
+			Description: `declared subtopic "repairs" subscribed to by another process`,
+		},
+		{
+			Path:        "krt.workflows[1].processes[2].subtopics",
+			Description: `declared subtopic "repairs" subscribed to by another process`,
+		},
+		{Path: "krt.apiVersion", Description: `set to "krt/v1"`},
+	}, rewrites)
+
+	migratedKrt, err := parse.ParseYamlToKrt(migratedYaml)
+	require.NoError(t, err)
+
+	expectedKrt, err := parse.ParseFileToKrt("./testdata/migrated_v1alpha1_krt.yaml")
+	require.NoError(t, err)
+
+	assert.Equal(t, expectedKrt, migratedKrt)
+}
+
+func TestMigrateFS_Includes(t *testing.T) {
+	fsys := fstest.MapFS{
+		"krt.yaml": {Data: []byte("version: v1.0.0\nworkflows:\n  - include: workflows/w.yaml\n")},
+		"workflows/w.yaml": {Data: []byte(`name: workflow
+processes:
+  - name: etl
+    subscriptions: [entrypoint]
+  - name: exitpoint
+    subscriptions: [etl.repairs]
+`)},
+	}
+
+	migratedFiles, rewrites, err := parse.MigrateFS(fsys, "krt.yaml")
+	require.NoError(t, err)
+
+	assert.Equal(t, []parse.Rewrite{
+		{
+			Path:        "krt.workflows[0].processes[0].subtopics",
+			Description: `declared subtopic "repairs" subscribed to by another process`,
+			File:        "workflows/w.yaml",
+		},
+		{Path: "krt.apiVersion", Description: `set to "krt/v1"`},
+	}, rewrites)
+	assert.Equal(t,
+		`krt.workflows[0].processes[0].subtopics: declared subtopic "repairs" subscribed to by another process, `+
+			`in workflow included from "workflows/w.yaml"`,
+		rewrites[0].String(),
+	)
+
+	require.Len(t, migratedFiles, 2)
+	assert.Equal(t, "apiVersion: krt/v1\nversion: v1.0.0\nworkflows:\n  - include: workflows/w.yaml\n", string(migratedFiles["krt.yaml"]),
+		"include entries are kept")
+
+	for file, content := range migratedFiles {
+		fsys[file] = &fstest.MapFile{Data: content}
+	}
+
+	var migrateAgain []parse.Rewrite
+
+	parsedKrt, err := parse.ParseFS(fsys, "krt.yaml", parse.WithRewriteHandler(func(rewrite parse.Rewrite) {
+		migrateAgain = append(migrateAgain, rewrite)
+	}))
+	require.NoError(t, err)
+	assert.Empty(t, migrateAgain)
+	assert.Equal(t, []string{"repairs"}, parsedKrt.Workflows[0].Processes[0].Subtopics)
+}
+
+func TestMigrate_Includes(t *testing.T) {
+	_, _, err := parse.Migrate([]byte("workflows:\n  - include: workflows/w.yaml\n"))
+	assert.ErrorIs(t, err, errors.ErrInvalidInclude)
+	assert.ErrorContains(t, err, "see ParseFS and MigrateFS")
+}
+
+func TestMigrate_CurrentAPIVersion(t *testing.T) {
+	currentYaml, err := os.ReadFile("./testdata/correct_krt.yaml")
+	require.NoError(t, err)
+
+	migratedYaml, rewrites, err := parse.Migrate(currentYaml)
+	require.NoError(t, err)
+
+	assert.Empty(t, rewrites)
+	assert.Equal(t, currentYaml, migratedYaml)
+}
+
+func TestMigrate_UnsupportedAPIVersion(t *testing.T) {
+	_, _, err := parse.Migrate([]byte("apiVersion: krt/v9\nversion: v1.0.0\n"))
+	assert.ErrorIs(t, err, errors.ErrUnsupportedAPIVersion)
+}
+
+func TestParseYamlToKrt_MigratesLegacyKrt(t *testing.T) {
+	var rewrites []parse.Rewrite
+
+	parsedKrt, err := parse.ParseFileToKrt("./testdata/v1alpha1_krt.yaml", parse.WithRewriteHandler(func(rewrite parse.Rewrite) {
+		rewrites = append(rewrites, rewrite)
+	}))
+	require.NoError(t, err)
+
+	assert.Len(t, rewrites, 3)
+	assert.Equal(t, krt.CurrentAPIVersion, parsedKrt.APIVersion)
+	assert.Equal(t, []string{"repairs"}, parsedKrt.Workflows[0].Processes[2].Subtopics)
+	assert.NoError(t, parsedKrt.Validate())
+}
@@ -0,0 +1,58 @@
+package parse
+
+// DefaultMaxSize is the max size of the files read, unless set with WithMaxSize.
+const DefaultMaxSize int64 = 10 << 20
+
+// Option customizes how a KRT is parsed.
+type Option func(*options)
+
+type options struct {
+	rewriteHandler     func(Rewrite)
+	deprecationHandler func(DeprecationWarning)
+	strictDeprecations bool
+	maxSize            int64
+}
+
+func newOptions(opts []Option) options {
+	parseOptions := options{
+		rewriteHandler:     func(Rewrite) {},
+		deprecationHandler: func(DeprecationWarning) {},
+		maxSize:            DefaultMaxSize,
+	}
+
+	for _, opt := range opts {
+		opt(&parseOptions)
+	}
+
+	return parseOptions
+}
+
+// WithRewriteHandler sets a function called with every rewrite made migrating an old KRT
+// to the current API version.
+func WithRewriteHandler(handler func(Rewrite)) Option {
+	return func(opts *options) {
+		opts.rewriteHandler = handler
+	}
+}
+
+// WithDeprecationHandler sets a function called with every deprecated field found.
+func WithDeprecationHandler(handler func(DeprecationWarning)) Option {
+	return func(opts *options) {
+		opts.deprecationHandler = handler
+	}
+}
+
+// WithStrictDeprecations makes parsing fail when deprecated fields are found.
+func WithStrictDeprecations() Option {
+	return func(opts *options) {
+		opts.strictDeprecations = true
+	}
+}
+
+// WithMaxSize sets the max size in bytes of each file read, KRTs and included files. Parsing fails
+// with ErrFileTooLarge if it is exceeded.
+func WithMaxSize(maxSize int64) Option {
+	return func(opts *options) {
+		opts.maxSize = maxSize
+	}
+}
@@ -0,0 +1,242 @@
+package parse
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/konstellation-io/krt/pkg/errors"
+	"github.com/konstellation-io/krt/pkg/krt"
+)
+
+// Overlay patches a base KRT, usually with the settings of an environment.
+//
+// An overlay has the shape of a KRT, but workflows and processes are matched by name instead of by
+// position, so it only declares the fields that change:
+//
+//	workflows:
+//	  - name: py-classificator
+//	    processes:
+//	      - name: etl
+//	        replicas: 3
+//
+// Mappings are merged, any other value replaces the value of the base KRT.
+type Overlay struct {
+	// Path identifies the overlay in errors, usually the file it was read from.
+	Path    string
+	Content []byte
+}
+
+// ParseYamlWithOverlays parses a Krt struct from a given yaml bytes, patched by the given overlays in order.
+//
+// Two overlays setting the same field to different values are reported as a conflict, as well
+// as overlays patching workflows or processes that do not exist in the base KRT.
+func ParseYamlWithOverlays(krtYaml []byte, overlays []Overlay, opts ...Option) (*krt.Krt, error) {
+	return parseKrt(krtYaml, overlays, nil, newOptions(opts))
+}
+
+// ParseFileWithOverlays parses a Krt struct from a given filename, patched by the given overlay files in order.
+//
+// Workflow includes of the KRT are resolved as ParseFS does, overlays patch the workflows once included.
+func ParseFileWithOverlays(yamlFile string, overlayFiles []string, opts ...Option) (*krt.Krt, error) {
+	parseOptions := newOptions(opts)
+	overlays := make([]Overlay, 0, len(overlayFiles))
+
+	for _, overlayFile := range overlayFiles {
+		content, err := readFile(os.DirFS(filepath.Dir(overlayFile)), filepath.Base(overlayFile), parseOptions.maxSize)
+		if err != nil {
+			return nil, err
+		}
+
+		overlays = append(overlays, Overlay{Path: overlayFile, Content: content})
+	}
+
+	return parseFS(os.DirFS(filepath.Dir(yamlFile)), filepath.Base(yamlFile), overlays, parseOptions)
+}
+
+// isNamedSequence returns whether the field is a list whose items are matched by name: the workflows
+// of the KRT and the processes of a workflow. Fields with the same key elsewhere, such as a config key
+// named "processes", are merged as any other field.
+func isNamedSequence(path string) bool {
+	workflowPath, processes := strings.CutSuffix(path, ".processes")
+	if !processes {
+		return path == "krt.workflows"
+	}
+
+	workflow, isWorkflow := strings.CutPrefix(workflowPath, "krt.workflows[")
+
+	return isWorkflow && strings.HasSuffix(workflow, "]") && !strings.ContainsAny(workflow[:len(workflow)-1], ".[]")
+}
+
+// overlayField is a field patched by an overlay.
+type overlayField struct {
+	overlay string
+	value   string
+}
+
+type overlayMerger struct {
+	overlay string
+	patched map[string]overlayField
+}
+
+// applyOverlays patches the document with every overlay in order.
+func applyOverlays(root *yaml.Node, overlays []Overlay) error {
+	if len(overlays) == 0 {
+		return nil
+	}
+
+	if root.Kind != yaml.DocumentNode || len(root.Content) == 0 || root.Content[0].Kind != yaml.MappingNode {
+		return errors.InvalidOverlayError(overlays[0].Path, "the base KRT is empty")
+	}
+
+	var (
+		totalError error
+		patched    = make(map[string]overlayField)
+	)
+
+	for _, overlay := range overlays {
+		var overlayRoot yaml.Node
+
+		if err := yaml.Unmarshal(overlay.Content, &overlayRoot); err != nil {
+			totalError = errors.Join(totalError, fmt.Errorf("%s: %w", overlay.Path, errors.InvalidYamlError(err)))
+			continue
+		}
+
+		if len(overlayRoot.Content) == 0 {
+			continue
+		}
+
+		if overlayRoot.Content[0].Kind != yaml.MappingNode {
+			totalError = errors.Join(totalError, errors.InvalidOverlayError(overlay.Path, "it must be a mapping"))
+			continue
+		}
+
+		merger := &overlayMerger{overlay: overlay.Path, patched: patched}
+		totalError = errors.Join(totalError, merger.mergeMapping(root.Content[0], overlayRoot.Content[0], "krt"))
+	}
+
+	return totalError
+}
+
+func (m *overlayMerger) mergeMapping(base, overlay *yaml.Node, path string) error {
+	var totalError error
+
+	for idx := 0; idx+1 < len(overlay.Content); idx += 2 {
+		key, value := overlay.Content[idx].Value, overlay.Content[idx+1]
+		fieldPath := path + "." + key
+		baseValue := mappingValue(base, key)
+
+		switch {
+		case isNamedSequence(fieldPath):
+			totalError = errors.Join(totalError, m.mergeNamedSequence(baseValue, value, fieldPath))
+		case baseValue != nil && baseValue.Kind == yaml.MappingNode && value.Kind == yaml.MappingNode:
+			totalError = errors.Join(totalError, m.mergeMapping(baseValue, value, fieldPath))
+		default:
+			totalError = errors.Join(totalError, m.patch(fieldPath, value))
+			setMappingValue(base, key, value)
+		}
+	}
+
+	return totalError
+}
+
+// mergeNamedSequence merges every item of the overlay sequence with the item of the base sequence with the same name.
+func (m *overlayMerger) mergeNamedSequence(base, overlay *yaml.Node, path string) error {
+	if overlay.Kind != yaml.SequenceNode {
+		return errors.InvalidOverlayError(m.overlay, fmt.Sprintf("%s must be a list", path))
+	}
+
+	var totalError error
+
+	for _, item := range overlay.Content {
+		nameNode := mappingValue(item, "name")
+		if nameNode == nil || nameNode.Value == "" {
+			totalError = errors.Join(totalError, errors.InvalidOverlayError(m.overlay, fmt.Sprintf("items of %s must have a name", path)))
+			continue
+		}
+
+		itemPath := fmt.Sprintf("%s[%s]", path, nameNode.Value)
+
+		baseItem := findNamedItem(base, nameNode.Value)
+		if baseItem == nil {
+			totalError = errors.Join(totalError, errors.OverlayReferenceNotFoundError(m.overlay, itemPath))
+			continue
+		}
+
+		totalError = errors.Join(totalError, m.mergeMapping(baseItem, withoutName(item), itemPath))
+	}
+
+	return totalError
+}
+
+// patch records the field as patched by the overlay, reporting a conflict if another overlay
+// already patched it, or any field inside or containing it, with a different value.
+func (m *overlayMerger) patch(path string, value *yaml.Node) error {
+	encodedValue, err := yaml.Marshal(value)
+	if err != nil {
+		return errors.InvalidOverlayError(m.overlay, fmt.Sprintf("%s cannot be encoded", path))
+	}
+
+	field := overlayField{overlay: m.overlay, value: string(encodedValue)}
+
+	var totalError error
+
+	patchedPaths := make([]string, 0, len(m.patched))
+	for patchedPath := range m.patched {
+		patchedPaths = append(patchedPaths, patchedPath)
+	}
+
+	sort.Strings(patchedPaths)
+
+	for _, patchedPath := range patchedPaths {
+		previous := m.patched[patchedPath]
+		if previous.overlay == m.overlay || !overlapping(path, patchedPath) {
+			continue
+		}
+
+		if patchedPath != path || previous.value != field.value {
+			totalError = errors.Join(totalError, errors.OverlayConflictError(path, m.overlay, previous.overlay))
+		}
+	}
+
+	m.patched[path] = field
+
+	return totalError
+}
+
+// overlapping returns whether a path is the same as, inside or containing the other path.
+func overlapping(path, other string) bool {
+	isPrefix := func(prefix, path string) bool {
+		return strings.HasPrefix(path, prefix+".") || strings.HasPrefix(path, prefix+"[")
+	}
+
+	return path == other || isPrefix(path, other) || isPrefix(other, path)
+}
+
+func findNamedItem(sequence *yaml.Node, name string) *yaml.Node {
+	for _, item := range sequenceItems(sequence) {
+		if nameNode := mappingValue(item, "name"); nameNode != nil && nameNode.Value == name {
+			return item
+		}
+	}
+
+	return nil
+}
+
+// withoutName returns a copy of the mapping without its name, as it is only used to match items.
+func withoutName(mapping *yaml.Node) *yaml.Node {
+	withoutName := *mapping
+	withoutName.Content = make([]*yaml.Node, 0, len(mapping.Content))
+
+	for idx := 0; idx+1 < len(mapping.Content); idx += 2 {
+		if mapping.Content[idx].Value != "name" {
+			withoutName.Content = append(withoutName.Content, mapping.Content[idx], mapping.Content[idx+1])
+		}
+	}
+
+	return &withoutName
+}
@@ -0,0 +1,144 @@
+//go:build unit
+
+package parse_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/konstellation-io/krt/pkg/errors"
+	"github.com/konstellation-io/krt/pkg/parse"
+)
+
+func TestParseFileWithOverlays(t *testing.T) {
+	parsedKrt, err := parse.ParseFileWithOverlays(
+		"./testdata/correct_krt.yaml",
+		[]string{"./testdata/overlays/prod.yaml", "./testdata/overlays/gpu.yaml"},
+	)
+	require.NoError(t, err)
+	require.NoError(t, parsedKrt.Validate())
+
+	etl := parsedKrt.Workflows[0].Processes[1]
+	assert.Equal(t, 3, *etl.Replicas)
+	assert.Equal(t, "100m", etl.ResourceLimits.CPU.Request, "mappings are merged")
+	assert.Equal(t, "1", etl.ResourceLimits.CPU.Limit)
+	assert.Equal(t, map[string]string{"konstellation.io/pool": "batch"}, etl.NodeSelectors)
+	assert.Equal(t, map[string]string{"key1": "value1", "key2": "value2"}, etl.Config)
+
+	assert.True(t, *parsedKrt.Workflows[0].Processes[2].GPU)
+
+	assert.Equal(t, 1, *parsedKrt.Workflows[1].Processes[1].Replicas, "other workflows are not patched")
+}
+
+func TestParseYamlWithOverlays_Errors(t *testing.T) {
+	base := []byte(`
+workflows:
+  - name: workflow
+    processes:
+      - name: etl
+        replicas: 1
+`)
+
+	testCases := []struct {
+		name        string
+		overlays    []parse.Overlay
+		errorType   error
+		errorString string
+	}{
+		{
+			name: "non existent workflow",
+			overlays: []parse.Overlay{
+				{Path: "prod.yaml", Content: []byte("workflows:\n  - name: other\n")},
+			},
+			errorType:   errors.ErrOverlayReferenceNotFound,
+			errorString: errors.OverlayReferenceNotFoundError("prod.yaml", "krt.workflows[other]").Error(),
+		},
+		{
+			name: "non existent process",
+			overlays: []parse.Overlay{
+				{Path: "prod.yaml", Content: []byte("workflows:\n  - name: workflow\n    processes:\n      - name: other\n")},
+			},
+			errorType:   errors.ErrOverlayReferenceNotFound,
+			errorString: errors.OverlayReferenceNotFoundError("prod.yaml", "krt.workflows[workflow].processes[other]").Error(),
+		},
+		{
+			name: "workflow without name",
+			overlays: []parse.Overlay{
+				{Path: "prod.yaml", Content: []byte("workflows:\n  - type: data\n")},
+			},
+			errorType:   errors.ErrInvalidOverlay,
+			errorString: errors.InvalidOverlayError("prod.yaml", "items of krt.workflows must have a name").Error(),
+		},
+		{
+			name: "overlays setting different values",
+			overlays: []parse.Overlay{
+				{Path: "prod.yaml", Content: []byte(etlReplicasOverlay(3))},
+				{Path: "gpu.yaml", Content: []byte(etlReplicasOverlay(2))},
+			},
+			errorType: errors.ErrOverlayConflict,
+			errorString: errors.OverlayConflictError(
+				"krt.workflows[workflow].processes[etl].replicas", "gpu.yaml", "prod.yaml",
+			).Error(),
+		},
+		{
+			name: "invalid yaml",
+			overlays: []parse.Overlay{
+				{Path: "prod.yaml", Content: []byte("workflows: [")},
+			},
+			errorType:   errors.ErrInvalidYaml,
+			errorString: "prod.yaml: ",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			parsedKrt, err := parse.ParseYamlWithOverlays(base, tc.overlays)
+			assert.Nil(t, parsedKrt)
+			assert.ErrorIs(t, err, tc.errorType)
+			assert.ErrorContains(t, err, tc.errorString)
+		})
+	}
+}
+
+func TestParseYamlWithOverlays_SameValue(t *testing.T) {
+	parsedKrt, err := parse.ParseYamlWithOverlays(
+		[]byte("workflows:\n  - name: workflow\n    processes:\n      - name: etl\n        replicas: 1\n"),
+		[]parse.Overlay{
+			{Path: "prod.yaml", Content: []byte(etlReplicasOverlay(3))},
+			{Path: "gpu.yaml", Content: []byte(etlReplicasOverlay(3))},
+		},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, 3, *parsedKrt.Workflows[0].Processes[0].Replicas)
+}
+
+func TestParseYamlWithOverlays_FieldsNamedAsLists(t *testing.T) {
+	parsedKrt, err := parse.ParseYamlWithOverlays(
+		[]byte("config:\n  processes: \"4\"\nworkflows:\n  - name: workflow\n    processes:\n      - name: etl\n"),
+		[]parse.Overlay{
+			{Path: "prod.yaml", Content: []byte(`
+config:
+  processes: "8"
+workflows:
+  - name: workflow
+    labels:
+      workflows: batch
+    processes:
+      - name: etl
+        config:
+          processes: "2"
+`)},
+		},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"processes": "8"}, parsedKrt.Config)
+	assert.Equal(t, map[string]string{"workflows": "batch"}, parsedKrt.Workflows[0].Labels)
+	assert.Equal(t, map[string]string{"processes": "2"}, parsedKrt.Workflows[0].Processes[0].Config)
+}
+
+func etlReplicasOverlay(replicas int) string {
+	return fmt.Sprintf("workflows:\n  - name: workflow\n    processes:\n      - name: etl\n        replicas: %d\n", replicas)
+}
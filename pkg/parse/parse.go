@@ -1,7 +1,11 @@
 package parse
 
 import (
+	"io"
+	"io/fs"
 	"os"
+	"path"
+	"path/filepath"
 
 	"github.com/creasty/defaults"
 	"gopkg.in/yaml.v3"
@@ -11,30 +15,187 @@ import (
 )
 
 // ParseYamlToKrt parses a Krt struct from a given yaml bytes.
-func ParseYamlToKrt(krtYaml []byte) (*krt.Krt, error) {
+//
+// KRTs of older API versions are migrated to the current one before being parsed.
+func ParseYamlToKrt(krtYaml []byte, opts ...Option) (*krt.Krt, error) {
+	return parseKrt(krtYaml, nil, nil, newOptions(opts))
+}
+
+// parseKrt parses a Krt struct from a given yaml bytes, with the workflow includes resolved by
+// the given loader, migrated to the current API version and patched by the given overlays.
+func parseKrt(krtYaml []byte, overlays []Overlay, loader *includeLoader, parseOptions options) (*krt.Krt, error) {
+	var root yaml.Node
+
+	err := yaml.Unmarshal(krtYaml, &root)
+	if err != nil {
+		return nil, errors.InvalidYamlError(err)
+	}
+
+	return parseDocument(&root, overlays, loader, parseOptions)
+}
+
+// parseDocument parses a Krt struct from a yaml document, see parseKrt.
+func parseDocument(root *yaml.Node, overlays []Overlay, loader *includeLoader, parseOptions options) (*krt.Krt, error) {
 	// talk about this shadow import
 	var parsedKrt krt.Krt
 
-	err := yaml.Unmarshal(krtYaml, &parsedKrt)
+	workflowFiles, err := resolveIncludes(root, loader)
 	if err != nil {
-		return nil, errors.InvalidYamlError(err)
+		return nil, err
+	}
+
+	rewrites, err := migrateDocument(root, workflowFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rewrite := range rewrites {
+		parseOptions.rewriteHandler(rewrite)
+	}
+
+	if err := applyOverlays(root, overlays); err != nil {
+		return nil, err
 	}
 
-	defaults.MustSet(&parsedKrt)
+	if err := checkDeprecations(root, parseOptions); err != nil {
+		return nil, err
+	}
+
+	if len(root.Content) > 0 {
+		if err := root.Decode(&parsedKrt); err != nil {
+			return nil, errors.InvalidYamlError(err)
+		}
+	}
+
+	// overlays patch existing workflows only, so they keep the order of the resolved includes
+	for idx := 0; idx < len(workflowFiles) && idx < len(parsedKrt.Workflows); idx++ {
+		parsedKrt.Workflows[idx].File = workflowFiles[idx]
+	}
+
+	setDefaults(&parsedKrt)
 
 	return &parsedKrt, nil
 }
 
-// ParseFileToKrt parses a Krt struct from a given filename.
+// setDefaults sets the default values of the KRT, except for process templates and the processes
+// extending them, as a default would take precedence over the value of the template. Defaults are
+// set to those processes once expanded.
+func setDefaults(parsedKrt *krt.Krt) {
+	templates := parsedKrt.ProcessTemplates
+	parsedKrt.ProcessTemplates = nil
+
+	extendingProcesses := make(map[*krt.Process]krt.Process)
+
+	for workflowIdx := range parsedKrt.Workflows {
+		for processIdx := range parsedKrt.Workflows[workflowIdx].Processes {
+			process := &parsedKrt.Workflows[workflowIdx].Processes[processIdx]
+			if process.Extends != "" {
+				extendingProcesses[process] = *process
+				*process = krt.Process{}
+			}
+		}
+	}
+
+	defaults.MustSet(parsedKrt)
+
+	for process, original := range extendingProcesses {
+		*process = original
+	}
+
+	parsedKrt.ProcessTemplates = templates
+}
+
+// checkDeprecations reports the deprecated fields of the document, failing if deprecations are strict.
+func checkDeprecations(root *yaml.Node, parseOptions options) error {
+	var totalError error
+
+	for _, warning := range findDeprecations(root) {
+		parseOptions.deprecationHandler(warning)
+
+		if parseOptions.strictDeprecations {
+			totalError = errors.Join(totalError, warning.error())
+		}
+	}
+
+	return totalError
+}
+
+// ParseFileToKrt parses a Krt struct from a given filename, resolving its workflow includes as ParseFS does.
 //
 // File must be in yaml format.
-func ParseFileToKrt(yamlFile string) (*krt.Krt, error) {
-	krtYml, err := os.ReadFile(yamlFile)
+func ParseFileToKrt(yamlFile string, opts ...Option) (*krt.Krt, error) {
+	return parseFS(os.DirFS(filepath.Dir(yamlFile)), filepath.Base(yamlFile), nil, newOptions(opts))
+}
+
+// ParseReader parses a Krt struct from the yaml read from the given reader, up to the max size set
+// with WithMaxSize.
+//
+// Workflow includes cannot be resolved, use ParseFS to parse KRTs split in several files.
+func ParseReader(reader io.Reader, opts ...Option) (*krt.Krt, error) {
+	parseOptions := newOptions(opts)
+
+	krtYaml, err := readLimited(reader, parseOptions.maxSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseKrt(krtYaml, nil, nil, parseOptions)
+}
+
+// ParseFS parses a Krt struct from a file of the given file system, resolving its workflow includes.
+//
+// Workflows can be declared in other files with an include entry, whose path is relative to the root file:
+//
+//	workflows:
+//	  - include: workflows/serving.yaml
+//
+// An included file holds a workflow, or a list of workflows that can include other files in turn. Included
+// files must be inside the directory of the root file, and cannot include each other in a cycle.
+func ParseFS(fsys fs.FS, krtFile string, opts ...Option) (*krt.Krt, error) {
+	return parseFS(fsys, krtFile, nil, newOptions(opts))
+}
+
+// parseFS parses a Krt struct from a file of the given file system, patched by the given overlays.
+func parseFS(fsys fs.FS, krtFile string, overlays []Overlay, parseOptions options) (*krt.Krt, error) {
+	krtYaml, err := readFile(fsys, krtFile, parseOptions.maxSize)
+	if err != nil {
+		return nil, err
+	}
+
+	loader := &includeLoader{
+		fsys:     fsys,
+		dir:      path.Dir(krtFile),
+		rootFile: krtFile,
+		maxSize:  parseOptions.maxSize,
+		included: make(map[string]string),
+	}
+
+	return parseKrt(krtYaml, overlays, loader, parseOptions)
+}
+
+// readFile reads a file of the given file system, failing if it is larger than the max size.
+func readFile(fsys fs.FS, name string, maxSize int64) ([]byte, error) {
+	file, err := fsys.Open(name)
 	if err != nil {
 		return nil, errors.ReadingFileError(err)
 	}
+	defer file.Close()
+
+	return readLimited(file, maxSize)
+}
+
+// readLimited reads the reader to the end, failing if more than max size bytes are read.
+func readLimited(reader io.Reader, maxSize int64) ([]byte, error) {
+	content, err := io.ReadAll(io.LimitReader(reader, maxSize+1))
+	if err != nil {
+		return nil, errors.ReadingFileError(err)
+	}
+
+	if int64(len(content)) > maxSize {
+		return nil, errors.ReadingFileError(errors.FileTooLargeError(maxSize))
+	}
 
-	return ParseYamlToKrt(krtYml)
+	return content, nil
 }
 
 // ParseKrtToYaml parses a Krt struct to yaml bytes.
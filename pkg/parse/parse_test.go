@@ -3,9 +3,13 @@
 package parse_test
 
 import (
+	"io"
+	"io/fs"
 	"os"
 	"strings"
 	"testing"
+	"testing/fstest"
+	"testing/iotest"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -27,9 +31,79 @@ func TestNonExistentFile(t *testing.T) {
 	krt, err := parse.ParseFileToKrt("./testdata/non_existent_krt.yaml")
 	require.Error(t, err)
 	assert.ErrorIs(t, err, errors.ErrReadingFile)
+	assert.ErrorIs(t, err, fs.ErrNotExist, "the underlying error is wrapped")
 	assert.Nil(t, krt)
 }
 
+func TestParseFileToKrt_Includes(t *testing.T) {
+	parsedKrt, err := parse.ParseFileToKrt("./testdata/includes/krt.yaml")
+	require.NoError(t, err)
+	require.NoError(t, parsedKrt.Validate())
+
+	assert.Equal(t, "workflows/serving.yaml", parsedKrt.Workflows[2].File)
+}
+
+func TestParseReader(t *testing.T) {
+	file, err := os.Open("./testdata/correct_krt.yaml")
+	require.NoError(t, err)
+
+	defer file.Close()
+
+	parsedKrt, err := parse.ParseReader(file)
+	require.NoError(t, err)
+	require.NoError(t, parsedKrt.Validate())
+}
+
+func TestParseReader_Errors(t *testing.T) {
+	testCases := []struct {
+		name       string
+		reader     io.Reader
+		opts       []parse.Option
+		errorTypes []error
+	}{
+		{
+			name:       "KRT larger than the max size",
+			reader:     strings.NewReader("description: a KRT larger than the max size"),
+			opts:       []parse.Option{parse.WithMaxSize(10)},
+			errorTypes: []error{errors.ErrReadingFile, errors.ErrFileTooLarge},
+		},
+		{
+			name:       "error reading",
+			reader:     iotest.ErrReader(io.ErrUnexpectedEOF),
+			errorTypes: []error{errors.ErrReadingFile, io.ErrUnexpectedEOF},
+		},
+		{
+			name:       "invalid yaml",
+			reader:     strings.NewReader("description: [invalid"),
+			errorTypes: []error{errors.ErrInvalidYaml},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			parsedKrt, err := parse.ParseReader(tc.reader, tc.opts...)
+			assert.Nil(t, parsedKrt)
+
+			for _, errorType := range tc.errorTypes {
+				assert.ErrorIs(t, err, errorType)
+			}
+		})
+	}
+}
+
+func TestParseFS_MaxSize(t *testing.T) {
+	fsys := fstest.MapFS{
+		"krt.yaml":      {Data: []byte("workflows:\n  - include: workflow.yaml\n")},
+		"workflow.yaml": {Data: []byte("name: a-workflow-larger-than-the-max-size\n")},
+	}
+
+	_, err := parse.ParseFS(fsys, "krt.yaml", parse.WithMaxSize(40))
+	assert.ErrorIs(t, err, errors.ErrFileTooLarge, "the max size applies to included files")
+
+	_, err = parse.ParseFS(fsys, "krt.yaml", parse.WithMaxSize(100))
+	assert.NoError(t, err)
+}
+
 func TestInvalidFile(t *testing.T) {
 	krt, err := parse.ParseFileToKrt("./testdata/invalid_file.yaml")
 	require.Error(t, err)
@@ -148,3 +222,40 @@ func TestValidKrtToYaml(t *testing.T) {
 
 	assert.Equal(t, expectedYamlString, actualYamlString)
 }
+
+func TestParseYamlToKrt_ProcessTemplates(t *testing.T) {
+	krtYml := []byte(`apiVersion: krt/v1
+version: v1.0.0
+description: Templates
+config: {}
+processTemplates:
+  worker:
+    replicas: 3
+    gpu: true
+workflows:
+  - name: workflow
+    type: data
+    config: {}
+    processes:
+      - name: etl
+        extends: worker
+        type: task
+        image: etl
+`)
+
+	parsedKrt, err := parse.ParseYamlToKrt(krtYml)
+	require.NoError(t, err)
+
+	etl := parsedKrt.Workflows[0].Processes[0]
+	assert.Nil(t, etl.Replicas, "defaults are not set to processes extending a template")
+
+	expanded, err := parsedKrt.ExpandedProcess("workflow", "etl")
+	require.NoError(t, err)
+	assert.Equal(t, 3, *expanded.Replicas)
+	assert.True(t, *expanded.GPU)
+
+	krtYaml, err := parse.ParseKrtToYaml(parsedKrt)
+	require.NoError(t, err)
+	assert.Contains(t, string(krtYaml), "extends: worker")
+	assert.Contains(t, string(krtYaml), "processTemplates:")
+}
@@ -0,0 +1,389 @@
+package parse
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/creasty/defaults"
+	"gopkg.in/yaml.v3"
+
+	"github.com/konstellation-io/krt/internal/kubeutil"
+	"github.com/konstellation-io/krt/pkg/errors"
+	"github.com/konstellation-io/krt/pkg/krt"
+)
+
+// Template is a KRT with placeholders for the parameters it declares, rendered with a set of values:
+//
+//	parameters:
+//	  - name: customer
+//	    required: true
+//	  - name: replicas
+//	    type: int
+//	    default: 2
+//	krt:
+//	  description: Email classificator for ${customer}
+//	  ...
+//	        replicas: ${replicas}
+//
+// Placeholders can be used in any value of the KRT. A plain value made of a single placeholder takes
+// the type of the parameter, so int parameters can be used in numeric fields. "$${" is rendered as "${".
+type Template struct {
+	Parameters []TemplateParameter `yaml:"parameters"`
+	KRT        yaml.Node           `yaml:"krt"`
+}
+
+// TemplateParameter is a value of a template set when rendering it.
+//
+// Parameters without value are rendered with their default, and fail if they are required. Optional
+// parameters without default are rendered empty.
+type TemplateParameter struct {
+	Name        string        `yaml:"name"`
+	Type        ParameterType `yaml:"type" default:"string"`
+	Description string        `yaml:"description,omitempty"`
+	Required    bool          `yaml:"required,omitempty"`
+	Default     *string       `yaml:"default,omitempty"`
+	// Values are the values allowed for enum parameters.
+	Values []string `yaml:"values,omitempty"`
+}
+
+type ParameterType string
+
+const (
+	ParameterTypeString   ParameterType = "string"
+	ParameterTypeInt      ParameterType = "int"
+	ParameterTypeQuantity ParameterType = "quantity"
+	ParameterTypeEnum     ParameterType = "enum"
+)
+
+func (pt ParameterType) IsValid() bool {
+	var parameterTypeMap = map[string]ParameterType{
+		string(ParameterTypeString):   ParameterTypeString,
+		string(ParameterTypeInt):      ParameterTypeInt,
+		string(ParameterTypeQuantity): ParameterTypeQuantity,
+		string(ParameterTypeEnum):     ParameterTypeEnum,
+	}
+
+	_, ok := parameterTypeMap[string(pt)]
+
+	return ok
+}
+
+// ParseTemplate parses a template from a given yaml bytes, checking its parameters.
+func ParseTemplate(templateYaml []byte) (*Template, error) {
+	var template Template
+
+	if err := yaml.Unmarshal(templateYaml, &template); err != nil {
+		return nil, errors.InvalidYamlError(err)
+	}
+
+	for idx := range template.Parameters {
+		defaults.MustSet(&template.Parameters[idx])
+	}
+
+	if err := template.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &template, nil
+}
+
+// ParseTemplateFile parses a template from a given filename.
+func ParseTemplateFile(templateFile string) (*Template, error) {
+	templateYaml, err := os.ReadFile(templateFile)
+	if err != nil {
+		return nil, errors.ReadingFileError(err)
+	}
+
+	return ParseTemplate(templateYaml)
+}
+
+// ParseValues parses the values of template parameters from a given yaml bytes, a mapping of
+// parameter names to values.
+func ParseValues(valuesYaml []byte) (map[string]string, error) {
+	values := make(map[string]string)
+
+	if err := yaml.Unmarshal(valuesYaml, &values); err != nil {
+		return nil, errors.InvalidYamlError(err)
+	}
+
+	return values, nil
+}
+
+// ParseValuesFile parses the values of template parameters from a given filename.
+func ParseValuesFile(valuesFile string) (map[string]string, error) {
+	valuesYaml, err := os.ReadFile(valuesFile)
+	if err != nil {
+		return nil, errors.ReadingFileError(err)
+	}
+
+	return ParseValues(valuesYaml)
+}
+
+// Validate checks the parameter declarations and that every placeholder refers to a declared parameter.
+func (t *Template) Validate() error {
+	var totalError error
+
+	if t.KRT.Kind == 0 {
+		totalError = errors.Join(totalError, errors.MissingRequiredFieldError("krt"))
+	}
+
+	declared := make(map[string]bool, len(t.Parameters))
+
+	for idx, parameter := range t.Parameters {
+		location := fmt.Sprintf("parameters[%d]", idx)
+
+		if declared[parameter.Name] {
+			totalError = errors.Join(totalError, errors.InvalidTemplateParameterError(parameter.Name, "declared more than once", location))
+		}
+
+		declared[parameter.Name] = true
+
+		totalError = errors.Join(totalError, parameter.validate(location))
+	}
+
+	usages := t.usages()
+
+	for _, name := range sortedKeys(usages) {
+		if !declared[name] {
+			totalError = errors.Join(totalError, errors.UndeclaredTemplateParameterError(name, strings.Join(usages[name], ", ")))
+		}
+	}
+
+	return totalError
+}
+
+func (p *TemplateParameter) validate(location string) error {
+	if !parameterNameRegexp().MatchString(p.Name) {
+		return errors.InvalidTemplateParameterError(p.Name, "invalid name", location+".name")
+	}
+
+	if !p.Type.IsValid() {
+		return errors.InvalidTemplateParameterError(p.Name, fmt.Sprintf("invalid type %q", p.Type), location+".type")
+	}
+
+	if p.Type == ParameterTypeEnum && len(p.Values) == 0 {
+		return errors.InvalidTemplateParameterError(p.Name, "enum parameters must declare their values", location+".values")
+	}
+
+	if p.Type != ParameterTypeEnum && len(p.Values) > 0 {
+		return errors.InvalidTemplateParameterError(p.Name, "only enum parameters can declare values", location+".values")
+	}
+
+	if p.Default == nil {
+		return nil
+	}
+
+	if p.Required {
+		return errors.InvalidTemplateParameterError(p.Name, "required parameters cannot have a default", location+".default")
+	}
+
+	if reason := p.invalidValueReason(*p.Default); reason != "" {
+		return errors.InvalidTemplateParameterError(p.Name, "invalid default, "+reason, location+".default")
+	}
+
+	return nil
+}
+
+// invalidValueReason returns why the value does not match the parameter type, if it does not.
+func (p *TemplateParameter) invalidValueReason(value string) string {
+	switch p.Type {
+	case ParameterTypeInt:
+		if _, err := strconv.Atoi(value); err != nil {
+			return fmt.Sprintf("%q is not an int", value)
+		}
+	case ParameterTypeQuantity:
+		if err := kubeutil.ValidateQuantity(value); err != nil {
+			return fmt.Sprintf("%q is not a quantity", value)
+		}
+	case ParameterTypeEnum:
+		for _, allowed := range p.Values {
+			if value == allowed {
+				return ""
+			}
+		}
+
+		return fmt.Sprintf("%q is not one of %s", value, strings.Join(p.Values, ", "))
+	case ParameterTypeString:
+	}
+
+	return ""
+}
+
+// usages returns the paths of the values where each parameter is used.
+func (t *Template) usages() map[string][]string {
+	usages := make(map[string][]string)
+
+	if t.KRT.Kind == 0 {
+		return usages
+	}
+
+	placeholders := placeholderRegexp()
+
+	walkNodes(&t.KRT, "krt", func(path string, node *yaml.Node) {
+		if node.Kind != yaml.ScalarNode {
+			return
+		}
+
+		for _, match := range placeholders.FindAllStringSubmatch(node.Value, -1) {
+			if isEscapedPlaceholder(match[0]) {
+				continue
+			}
+
+			usages[match[1]] = append(usages[match[1]], path)
+		}
+	})
+
+	return usages
+}
+
+// RenderTemplate renders the template with the given values into a Krt struct, parsed as ParseYamlToKrt does.
+//
+// Values are checked against the type of their parameter, and errors name the parameter and the paths where
+// it is used. As with any parsed KRT, the result must be validated.
+func RenderTemplate(template *Template, values map[string]string, opts ...Option) (*krt.Krt, error) {
+	if err := template.Validate(); err != nil {
+		return nil, err
+	}
+
+	resolved, err := template.resolveValues(values)
+	if err != nil {
+		return nil, err
+	}
+
+	rendered := cloneNode(&template.KRT)
+	placeholders := placeholderRegexp()
+
+	walkNodes(rendered, "krt", func(_ string, node *yaml.Node) {
+		if node.Kind == yaml.ScalarNode {
+			template.renderScalar(node, resolved, placeholders)
+		}
+	})
+
+	return parseDocument(&yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{rendered}}, nil, nil, newOptions(opts))
+}
+
+// resolveValues returns the value each parameter is rendered with, nil for optional parameters without value.
+func (t *Template) resolveValues(values map[string]string) (map[string]*string, error) {
+	var totalError error
+
+	usages := t.usages()
+	resolved := make(map[string]*string, len(t.Parameters))
+	declared := make(map[string]bool, len(t.Parameters))
+
+	for idx := range t.Parameters {
+		parameter := &t.Parameters[idx]
+		declared[parameter.Name] = true
+
+		location := strings.Join(usages[parameter.Name], ", ")
+		if location == "" {
+			location = fmt.Sprintf("parameters[%d]", idx)
+		}
+
+		value, ok := values[parameter.Name]
+
+		switch {
+		case ok:
+			if reason := parameter.invalidValueReason(value); reason != "" {
+				totalError = errors.Join(totalError, errors.InvalidTemplateValueError(parameter.Name, reason, location))
+				continue
+			}
+
+			resolved[parameter.Name] = &value
+		case parameter.Default != nil:
+			resolved[parameter.Name] = parameter.Default
+		case parameter.Required:
+			totalError = errors.Join(totalError, errors.MissingTemplateParameterError(parameter.Name, location))
+		}
+	}
+
+	for _, name := range sortedKeys(values) {
+		if !declared[name] {
+			totalError = errors.Join(totalError, errors.UnknownTemplateValueError(name))
+		}
+	}
+
+	return resolved, totalError
+}
+
+// renderScalar replaces the placeholders of the scalar by the values of their parameters.
+func (t *Template) renderScalar(node *yaml.Node, resolved map[string]*string, placeholders *regexp.Regexp) {
+	match := placeholders.FindStringSubmatch(node.Value)
+	isPlain := node.Style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle) == 0
+
+	if match != nil && match[0] == node.Value && !isEscapedPlaceholder(match[0]) && isPlain {
+		value := resolved[match[1]]
+
+		switch {
+		case value == nil:
+			node.Tag, node.Value = "!!null", ""
+		case t.parameter(match[1]).Type == ParameterTypeInt:
+			node.Tag, node.Value = "!!int", *value
+		default:
+			node.Tag, node.Value = "!!str", *value
+		}
+
+		return
+	}
+
+	node.Value = placeholders.ReplaceAllStringFunc(node.Value, func(placeholder string) string {
+		if isEscapedPlaceholder(placeholder) {
+			return placeholder[1:]
+		}
+
+		if value := resolved[placeholder[2:len(placeholder)-1]]; value != nil {
+			return *value
+		}
+
+		return ""
+	})
+}
+
+func (t *Template) parameter(name string) *TemplateParameter {
+	for idx := range t.Parameters {
+		if t.Parameters[idx].Name == name {
+			return &t.Parameters[idx]
+		}
+	}
+
+	return nil
+}
+
+// placeholderRegexp matches placeholders, such as "${name}", and escaped placeholders, such as "$${name}".
+func placeholderRegexp() *regexp.Regexp {
+	return regexp.MustCompile(`\$?\$\{([^}]*)\}`)
+}
+
+func parameterNameRegexp() *regexp.Regexp {
+	return regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+}
+
+func isEscapedPlaceholder(placeholder string) bool {
+	return strings.HasPrefix(placeholder, "$$")
+}
+
+// cloneNode returns a deep copy of the node, so rendering does not modify the template.
+func cloneNode(node *yaml.Node) *yaml.Node {
+	clone := *node
+	clone.Content = make([]*yaml.Node, 0, len(node.Content))
+
+	for _, child := range node.Content {
+		clone.Content = append(clone.Content, cloneNode(child))
+	}
+
+	return &clone
+}
+
+func sortedKeys[V any](values map[string]V) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
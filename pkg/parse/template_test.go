@@ -0,0 +1,160 @@
+//go:build unit
+
+package parse_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/konstellation-io/krt/pkg/errors"
+	"github.com/konstellation-io/krt/pkg/parse"
+)
+
+func TestRenderTemplate(t *testing.T) {
+	template, err := parse.ParseTemplateFile("./testdata/templates/classificator.yaml")
+	require.NoError(t, err)
+
+	values, err := parse.ParseValuesFile("./testdata/templates/values.yaml")
+	require.NoError(t, err)
+
+	renderedKrt, err := parse.RenderTemplate(template, values)
+	require.NoError(t, err)
+	require.NoError(t, renderedKrt.Validate())
+
+	assert.Equal(t, "Email classificator for acme, price ${amount}.", renderedKrt.Description)
+	assert.Equal(t, map[string]string{"CUSTOMER": "acme", "TIER": "premium", "REGION": ""}, renderedKrt.Config)
+
+	etl := renderedKrt.Workflows[0].Processes[1]
+	assert.Equal(t, 3, *etl.Replicas)
+	assert.Equal(t, "1Gi", etl.ResourceLimits.Memory.Request)
+
+	renderedKrt, err = parse.RenderTemplate(template, map[string]string{"customer": "acme"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, *renderedKrt.Workflows[0].Processes[1].Replicas, "defaults are used for parameters without value")
+	assert.Equal(t, "basic", renderedKrt.Config["TIER"])
+}
+
+func TestRenderTemplate_ValueErrors(t *testing.T) {
+	template, err := parse.ParseTemplateFile("./testdata/templates/classificator.yaml")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name        string
+		values      map[string]string
+		errorType   error
+		errorString string
+	}{
+		{
+			name:        "missing required parameter",
+			values:      map[string]string{},
+			errorType:   errors.ErrMissingTemplateParameter,
+			errorString: errors.MissingTemplateParameterError("customer", "krt.description, krt.config.CUSTOMER").Error(),
+		},
+		{
+			name:      "int parameter with a value that is not an int",
+			values:    map[string]string{"customer": "acme", "replicas": "three"},
+			errorType: errors.ErrInvalidTemplateValue,
+			errorString: errors.InvalidTemplateValueError(
+				"replicas", `"three" is not an int`, "krt.workflows[0].processes[1].replicas",
+			).Error(),
+		},
+		{
+			name:      "quantity parameter with a value that is not a quantity",
+			values:    map[string]string{"customer": "acme", "memory": "1GB"},
+			errorType: errors.ErrInvalidTemplateValue,
+			errorString: errors.InvalidTemplateValueError(
+				"memory", `"1GB" is not a quantity`, "krt.workflows[0].processes[1].resourceLimits.memory.request",
+			).Error(),
+		},
+		{
+			name:        "enum parameter with a value not allowed",
+			values:      map[string]string{"customer": "acme", "tier": "gold"},
+			errorType:   errors.ErrInvalidTemplateValue,
+			errorString: errors.InvalidTemplateValueError("tier", `"gold" is not one of basic, premium`, "krt.config.TIER").Error(),
+		},
+		{
+			name:        "value for an undeclared parameter",
+			values:      map[string]string{"customer": "acme", "costumer": "acme"},
+			errorType:   errors.ErrUnknownTemplateValue,
+			errorString: errors.UnknownTemplateValueError("costumer").Error(),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			renderedKrt, err := parse.RenderTemplate(template, tc.values)
+			assert.Nil(t, renderedKrt)
+			assert.ErrorIs(t, err, tc.errorType)
+			assert.ErrorContains(t, err, tc.errorString)
+		})
+	}
+}
+
+func TestParseTemplate_Errors(t *testing.T) {
+	testCases := []struct {
+		name        string
+		template    string
+		errorType   error
+		errorString string
+	}{
+		{
+			name:        "placeholder of an undeclared parameter",
+			template:    "krt:\n  description: ${customer}\n",
+			errorType:   errors.ErrUndeclaredTemplateParameter,
+			errorString: errors.UndeclaredTemplateParameterError("customer", "krt.description").Error(),
+		},
+		{
+			name:        "parameter declared more than once",
+			template:    "parameters:\n  - name: customer\n  - name: customer\nkrt: {}\n",
+			errorType:   errors.ErrInvalidTemplateParameter,
+			errorString: errors.InvalidTemplateParameterError("customer", "declared more than once", "parameters[1]").Error(),
+		},
+		{
+			name:        "parameter of an invalid type",
+			template:    "parameters:\n  - name: customer\n    type: bool\nkrt: {}\n",
+			errorType:   errors.ErrInvalidTemplateParameter,
+			errorString: errors.InvalidTemplateParameterError("customer", `invalid type "bool"`, "parameters[0].type").Error(),
+		},
+		{
+			name:      "enum parameter without values",
+			template:  "parameters:\n  - name: tier\n    type: enum\nkrt: {}\n",
+			errorType: errors.ErrInvalidTemplateParameter,
+			errorString: errors.InvalidTemplateParameterError(
+				"tier", "enum parameters must declare their values", "parameters[0].values",
+			).Error(),
+		},
+		{
+			name:      "required parameter with a default",
+			template:  "parameters:\n  - name: customer\n    required: true\n    default: acme\nkrt: {}\n",
+			errorType: errors.ErrInvalidTemplateParameter,
+			errorString: errors.InvalidTemplateParameterError(
+				"customer", "required parameters cannot have a default", "parameters[0].default",
+			).Error(),
+		},
+		{
+			name:      "default that does not match the parameter type",
+			template:  "parameters:\n  - name: replicas\n    type: int\n    default: one\nkrt: {}\n",
+			errorType: errors.ErrInvalidTemplateParameter,
+			errorString: errors.InvalidTemplateParameterError(
+				"replicas", `invalid default, "one" is not an int`, "parameters[0].default",
+			).Error(),
+		},
+		{
+			name:        "template without krt",
+			template:    "parameters: []\n",
+			errorType:   errors.ErrMissingRequiredField,
+			errorString: errors.MissingRequiredFieldError("krt").Error(),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			template, err := parse.ParseTemplate([]byte(tc.template))
+			assert.Nil(t, template)
+			assert.ErrorIs(t, err, tc.errorType)
+			assert.ErrorContains(t, err, tc.errorString)
+		})
+	}
+}
@@ -0,0 +1,241 @@
+// Package signature signs KRTs and bundles with ed25519 detached signatures, and verifies them against
+// a set of trusted keys.
+//
+// Signatures are computed over a canonical serialization, see krt.Krt.Canonical and bundle.Manifest.Canonical,
+// so a KRT keeps its signature as long as its content does not change, however the file is formatted. The
+// serialization is preceded by the signature domain of the content, so a signature of a KRT is never valid
+// for a bundle manifest, or the other way around.
+package signature
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/konstellation-io/krt/pkg/errors"
+)
+
+// Algorithm is the only signature algorithm supported.
+const Algorithm = "ed25519"
+
+// Extension is the extension of detached signature files, appended to the name of the file signed.
+const Extension = ".sig"
+
+const (
+	privateKeyBlock = "PRIVATE KEY"
+	publicKeyBlock  = "PUBLIC KEY"
+	keyIDLength     = 16
+	domainPrefix    = "konstellation.io/krt/signature/"
+)
+
+// Signable is content with a canonical serialization to sign, such as a *krt.Krt or a *bundle.Manifest.
+//
+// The signature domain names the kind of content, it is signed along with the canonical serialization so
+// the signature of some content is never valid for content of another kind with the same serialization.
+type Signable interface {
+	Canonical() ([]byte, error)
+	SignatureDomain() string
+}
+
+// Signature is a detached signature, stored apart from the content signed.
+type Signature struct {
+	Algorithm string `yaml:"algorithm"`
+	// KeyID identifies the public key the signature is verified with, see KeyID.
+	KeyID string `yaml:"keyId"`
+	Value string `yaml:"signature"`
+}
+
+// KeyID returns the identifier of a public key, the start of the hex encoded SHA-256 of the key.
+func KeyID(publicKey ed25519.PublicKey) string {
+	sum := sha256.Sum256(publicKey)
+	return hex.EncodeToString(sum[:])[:keyIDLength]
+}
+
+// Sign signs the canonical serialization of the content with the private key.
+func Sign(content Signable, privateKey ed25519.PrivateKey) (*Signature, error) {
+	message, err := signedMessage(content)
+	if err != nil {
+		return nil, err
+	}
+
+	publicKey, _ := privateKey.Public().(ed25519.PublicKey)
+
+	return &Signature{
+		Algorithm: Algorithm,
+		KeyID:     KeyID(publicKey),
+		Value:     base64.StdEncoding.EncodeToString(ed25519.Sign(privateKey, message)),
+	}, nil
+}
+
+// signedMessage returns the bytes signed for the content, its canonical serialization preceded by its
+// signature domain.
+func signedMessage(content Signable) ([]byte, error) {
+	canonical, err := content.Canonical()
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(domainPrefix+content.SignatureDomain()+"\x00"), canonical...), nil
+}
+
+// Marshal returns the signature in the format of signature files.
+func (s *Signature) Marshal() ([]byte, error) {
+	return yaml.Marshal(s)
+}
+
+// ParseSignature parses a signature from the content of a signature file.
+func ParseSignature(signatureYaml []byte) (*Signature, error) {
+	var signature Signature
+
+	if err := yaml.Unmarshal(signatureYaml, &signature); err != nil {
+		return nil, errors.MalformedSignatureError(err.Error())
+	}
+
+	if signature.Algorithm != Algorithm {
+		return nil, errors.MalformedSignatureError(fmt.Sprintf("unsupported algorithm %q", signature.Algorithm))
+	}
+
+	return &signature, nil
+}
+
+// KeySet are the public keys whose signatures are trusted, by key ID.
+type KeySet struct {
+	keys map[string]ed25519.PublicKey
+}
+
+// NewKeySet returns a key set trusting the given public keys.
+func NewKeySet(publicKeys ...ed25519.PublicKey) *KeySet {
+	keySet := &KeySet{keys: make(map[string]ed25519.PublicKey, len(publicKeys))}
+	for _, publicKey := range publicKeys {
+		keySet.keys[KeyID(publicKey)] = publicKey
+	}
+
+	return keySet
+}
+
+// ParseKeySet parses a key set from PEM encoded public keys, usually a file with the keys of every
+// release manager.
+func ParseKeySet(keysPem []byte) (*KeySet, error) {
+	var publicKeys []ed25519.PublicKey
+
+	for block, rest := pem.Decode(keysPem); block != nil; block, rest = pem.Decode(rest) {
+		publicKey, err := parsePublicKey(block)
+		if err != nil {
+			return nil, err
+		}
+
+		publicKeys = append(publicKeys, publicKey)
+	}
+
+	if len(publicKeys) == 0 {
+		return nil, errors.InvalidKeyError("no PEM encoded public key found")
+	}
+
+	return NewKeySet(publicKeys...), nil
+}
+
+// KeyIDs returns the IDs of the trusted keys sorted alphabetically.
+func (ks *KeySet) KeyIDs() []string {
+	keyIDs := make([]string, 0, len(ks.keys))
+	for keyID := range ks.keys {
+		keyIDs = append(keyIDs, keyID)
+	}
+
+	sort.Strings(keyIDs)
+
+	return keyIDs
+}
+
+// Verify checks the signature of the content was made with one of the trusted keys.
+func (ks *KeySet) Verify(content Signable, signature *Signature) error {
+	if signature.Algorithm != Algorithm {
+		return errors.MalformedSignatureError(fmt.Sprintf("unsupported algorithm %q", signature.Algorithm))
+	}
+
+	publicKey, ok := ks.keys[signature.KeyID]
+	if !ok {
+		return errors.UntrustedKeyError(signature.KeyID)
+	}
+
+	value, err := base64.StdEncoding.DecodeString(signature.Value)
+	if err != nil {
+		return errors.MalformedSignatureError(err.Error())
+	}
+
+	message, err := signedMessage(content)
+	if err != nil {
+		return err
+	}
+
+	if !ed25519.Verify(publicKey, message, value) {
+		return errors.InvalidSignatureError(signature.KeyID)
+	}
+
+	return nil
+}
+
+// ParsePrivateKey parses a PEM encoded PKCS #8 ed25519 private key, such as the ones generated with
+// "openssl genpkey -algorithm ed25519".
+func ParsePrivateKey(keyPem []byte) (ed25519.PrivateKey, error) {
+	block, _ := pem.Decode(keyPem)
+	if block == nil || block.Type != privateKeyBlock {
+		return nil, errors.InvalidKeyError("no PEM encoded private key found")
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, errors.InvalidKeyError(err.Error())
+	}
+
+	privateKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, errors.InvalidKeyError(fmt.Sprintf("%T is not an ed25519 key", key))
+	}
+
+	return privateKey, nil
+}
+
+func parsePublicKey(block *pem.Block) (ed25519.PublicKey, error) {
+	if block.Type != publicKeyBlock {
+		return nil, errors.InvalidKeyError(fmt.Sprintf("unexpected PEM block %q", block.Type))
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, errors.InvalidKeyError(err.Error())
+	}
+
+	publicKey, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, errors.InvalidKeyError(fmt.Sprintf("%T is not an ed25519 key", key))
+	}
+
+	return publicKey, nil
+}
+
+// MarshalPrivateKey returns the private key PEM encoded in PKCS #8 form, as ParsePrivateKey reads it.
+func MarshalPrivateKey(privateKey ed25519.PrivateKey) ([]byte, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: privateKeyBlock, Bytes: der}), nil
+}
+
+// MarshalPublicKey returns the public key PEM encoded in PKIX form, as ParseKeySet reads it.
+func MarshalPublicKey(publicKey ed25519.PublicKey) ([]byte, error) {
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: publicKeyBlock, Bytes: der}), nil
+}